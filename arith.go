@@ -0,0 +1,334 @@
+package matrix
+
+/*
+Add returns a new matrix holding the sum of a and the passed value, which
+can be a float64, a float32, or a Mat of the same shape as a or one that can
+be broadcast to it, as described in (*Mat).Add. Unlike the Add method,
+neither a nor the passed value are modified. For example:
+
+	c := matrix.Add(a, b)
+*/
+func Add[T Float](a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return pure("Add()", a, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+AddE is the same as Add, except that it returns an error instead of exiting
+the program.
+*/
+func AddE[T Float](a *Mat[T], floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Add()", a, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+Sub returns a new matrix holding the difference of a and the passed value,
+in the same way as Add.
+*/
+func Sub[T Float](a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return pure("Sub()", a, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+SubE is the same as Sub, except that it returns an error instead of exiting
+the program.
+*/
+func SubE[T Float](a *Mat[T], floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Sub()", a, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+Mul returns a new matrix holding the element-wise product of a and the
+passed value, in the same way as Add.
+*/
+func Mul[T Float](a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return pure("Mul()", a, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+MulE is the same as Mul, except that it returns an error instead of exiting
+the program.
+*/
+func MulE[T Float](a *Mat[T], floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Mul()", a, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+Div returns a new matrix holding the element-wise quotient of a and the
+passed value, in the same way as Add.
+*/
+func Div[T Float](a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return pure("Div()", a, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+DivE is the same as Div, except that it returns an error instead of exiting
+the program.
+*/
+func DivE[T Float](a *Mat[T], floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Div()", a, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+Map returns a new matrix holding the values of m, after applying the passed
+function to each of them as in (*Mat).Map. m is not modified.
+*/
+func Map[T Float](m *Mat[T], f func(*T)) *Mat[T] {
+	return m.Copy().Map(f)
+}
+
+/*
+Full returns a new r by c matrix with all of its values set to val. It is
+the non-mutating counterpart of SetAll:
+
+	m := matrix.Full(2, 3, 1.0) // A 2 by 3 Matf64 of ones.
+*/
+func Full[T Float](r, c int, val T) *Mat[T] {
+	m, err := FullE(r, c, val)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+FullE is the same as Full, except that it returns an error instead of
+exiting the program.
+*/
+func FullE[T Float](r, c int, val T) (*Mat[T], error) {
+	m, err := newE[T]("Full()", []int{r, c})
+	if err != nil {
+		return nil, err
+	}
+	for i := range m.vals {
+		m.vals[i] = val
+	}
+	return m, nil
+}
+
+/*
+Plus returns a new matrix holding the sum of the receiver and the passed
+value, and is the same as matrix.Add(m, floatOrMat).
+*/
+func (m *Mat[T]) Plus(floatOrMat interface{}) *Mat[T] {
+	return pure("Plus()", m, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+PlusE is the same as Plus, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) PlusE(floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Plus()", m, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+Minus returns a new matrix holding the difference of the receiver and the
+passed value, and is the same as matrix.Sub(m, floatOrMat).
+*/
+func (m *Mat[T]) Minus(floatOrMat interface{}) *Mat[T] {
+	return pure("Minus()", m, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+MinusE is the same as Minus, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) MinusE(floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Minus()", m, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+Times returns a new matrix holding the element-wise product of the receiver
+and the passed value, and is the same as matrix.Mul(m, floatOrMat).
+*/
+func (m *Mat[T]) Times(floatOrMat interface{}) *Mat[T] {
+	return pure("Times()", m, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+TimesE is the same as Times, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) TimesE(floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("Times()", m, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+DividedBy returns a new matrix holding the element-wise quotient of the
+receiver and the passed value, and is the same as matrix.Div(m, floatOrMat).
+*/
+func (m *Mat[T]) DividedBy(floatOrMat interface{}) *Mat[T] {
+	return pure("DividedBy()", m, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+DividedByE is the same as DividedBy, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) DividedByE(floatOrMat interface{}) (*Mat[T], error) {
+	return pureE("DividedBy()", m, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+AddInto stores the sum of a and the passed value in dst, which must have the
+same shape as a, and returns dst. It is the same as Add, except that it does
+not allocate a new matrix, which makes it suitable for loops:
+
+	matrix.AddInto(dst, a, b) // dst = a + b
+
+dst may be a itself, or the passed value. In the latter case, a temporary
+copy of the passed value is made.
+*/
+func AddInto[T Float](dst, a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return into("AddInto()", dst, a, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+AddIntoE is the same as AddInto, except that it returns an error instead of
+exiting the program.
+*/
+func AddIntoE[T Float](dst, a *Mat[T], floatOrMat interface{}) error {
+	return intoE("AddInto()", dst, a, floatOrMat, (*Mat[T]).AddE)
+}
+
+/*
+SubInto stores the difference of a and the passed value in dst, in the same
+way as AddInto.
+*/
+func SubInto[T Float](dst, a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return into("SubInto()", dst, a, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+SubIntoE is the same as SubInto, except that it returns an error instead of
+exiting the program.
+*/
+func SubIntoE[T Float](dst, a *Mat[T], floatOrMat interface{}) error {
+	return intoE("SubInto()", dst, a, floatOrMat, (*Mat[T]).SubE)
+}
+
+/*
+MulInto stores the element-wise product of a and the passed value in dst, in
+the same way as AddInto.
+*/
+func MulInto[T Float](dst, a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return into("MulInto()", dst, a, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+MulIntoE is the same as MulInto, except that it returns an error instead of
+exiting the program.
+*/
+func MulIntoE[T Float](dst, a *Mat[T], floatOrMat interface{}) error {
+	return intoE("MulInto()", dst, a, floatOrMat, (*Mat[T]).MulE)
+}
+
+/*
+DivInto stores the element-wise quotient of a and the passed value in dst,
+in the same way as AddInto.
+*/
+func DivInto[T Float](dst, a *Mat[T], floatOrMat interface{}) *Mat[T] {
+	return into("DivInto()", dst, a, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+DivIntoE is the same as DivInto, except that it returns an error instead of
+exiting the program.
+*/
+func DivIntoE[T Float](dst, a *Mat[T], floatOrMat interface{}) error {
+	return intoE("DivInto()", dst, a, floatOrMat, (*Mat[T]).DivE)
+}
+
+/*
+MapInto stores the values of m in dst, which must have the same shape as m,
+after applying the passed function to each of them, and returns dst.
+*/
+func MapInto[T Float](dst, m *Mat[T], f func(*T)) *Mat[T] {
+	if err := MapIntoE(dst, m, f); err != nil {
+		handleErr(err)
+	}
+	return dst
+}
+
+/*
+MapIntoE is the same as MapInto, except that it returns an error instead of
+exiting the program.
+*/
+func MapIntoE[T Float](dst, m *Mat[T], f func(*T)) error {
+	if err := copyInto("MapInto()", dst, m); err != nil {
+		return err
+	}
+	dst.Map(f)
+	return nil
+}
+
+// pure and pureE apply one of the mutating arithmetic methods to a copy of
+// a, replacing the name of the method in the returned errors with op.
+func pure[T Float](
+	op string, a *Mat[T], floatOrMat interface{}, f func(*Mat[T], interface{}) error,
+) *Mat[T] {
+	m, err := pureE(op, a, floatOrMat, f)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+func pureE[T Float](
+	op string, a *Mat[T], floatOrMat interface{}, f func(*Mat[T], interface{}) error,
+) (*Mat[T], error) {
+	m := a.Copy()
+	if err := f(m, floatOrMat); err != nil {
+		return nil, renameOp(err, op)
+	}
+	return m, nil
+}
+
+// into and intoE apply one of the mutating arithmetic methods to dst, after
+// copying the values of a into it.
+func into[T Float](
+	op string, dst, a *Mat[T], floatOrMat interface{}, f func(*Mat[T], interface{}) error,
+) *Mat[T] {
+	if err := intoE(op, dst, a, floatOrMat, f); err != nil {
+		handleErr(err)
+	}
+	return dst
+}
+
+func intoE[T Float](
+	op string, dst, a *Mat[T], floatOrMat interface{}, f func(*Mat[T], interface{}) error,
+) error {
+	if b, ok := floatOrMat.(*Mat[T]); ok && overlaps(b, dst) && !(b == dst && a == dst) {
+		floatOrMat = b.Copy()
+	}
+	if err := copyInto(op, dst, a); err != nil {
+		return err
+	}
+	return renameOp(f(dst, floatOrMat), op)
+}
+
+// copyInto copies the values of m into dst, which must have the same shape.
+// m may be a view which overlaps dst, in which case it is copied first.
+func copyInto[T Float](op string, dst, m *Mat[T]) error {
+	if dst.r != m.r || dst.c != m.c {
+		return &ShapeMismatchError{Op: op, Want: []int{m.r, m.c}, Got: []int{dst.r, dst.c}}
+	}
+	if dst != m {
+		if overlaps(dst, m) {
+			m = m.Copy()
+		}
+		eachPair(dst, m, func(a, b []T) { copy(a, b) })
+	}
+	return nil
+}
+
+// renameOp sets the Op of the errors of this package to op.
+func renameOp(err error, op string) error {
+	switch e := err.(type) {
+	case *ShapeMismatchError:
+		e.Op = op
+	case *UnsupportedTypeError:
+		e.Op = op
+	}
+	return err
+}
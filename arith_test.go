@@ -0,0 +1,93 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPureArithf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+	b := Matf64FromData([][]float64{
+		{4, 3},
+		{2, 1},
+	})
+	assert.Equal(t, []float64{5, 5, 5, 5}, Add(a, b).vals, "should be equal")
+	assert.Equal(t, []float64{-3, -1, 1, 3}, Sub(a, b).vals, "should be equal")
+	assert.Equal(t, []float64{4, 6, 6, 4}, a.Times(b).vals, "should be equal")
+	assert.Equal(t, []float64{0.5, 1, 1.5, 2}, a.DividedBy(2.0).vals, "should be equal")
+	assert.Equal(t, []float64{2, 3, 4, 5}, a.Plus(Full(1, 1, 1.0)).vals, "broadcast")
+	assert.Equal(t, []float64{0, 0, 2, 2}, a.Minus(a.View(0, 1, 0, 2)).vals, "broadcast")
+	assert.Equal(t, []float64{1, 4, 9, 16}, Map(a, func(v *float64) { *v *= *v }).vals,
+		"should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4}, a.vals, "a should not change")
+	assert.Equal(t, []float64{4, 3, 2, 1}, b.vals, "b should not change")
+
+	_, err := AddE(a, Newf64(3, 3))
+	assert.Equal(t, "Add()", err.(*ShapeMismatchError).Op, "should be equal")
+	_, err = a.TimesE("a")
+	assert.Equal(t, "Times()", err.(*UnsupportedTypeError).Op, "should be equal")
+
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { Div(a, Newf64(3, 3)) }, "shapes mismatch")
+	assert.Panics(t, func() { a.Plus(1) }, "unsupported type")
+}
+
+func TestIntoArithf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+	b := Matf64FromData([]float64{1, 2})
+	dst := Newf64(2, 2)
+	AddInto(dst, a, b)
+	assert.Equal(t, []float64{2, 4, 4, 6}, dst.vals, "should be equal")
+	MulInto(dst, a, 2.0)
+	assert.Equal(t, []float64{2, 4, 6, 8}, dst.vals, "should be equal")
+	SubInto(dst, a, dst)
+	assert.Equal(t, []float64{-1, -2, -3, -4}, dst.vals, "dst aliases the operand")
+	DivInto(dst, dst, -1.0)
+	assert.Equal(t, []float64{1, 2, 3, 4}, dst.vals, "dst aliases a")
+	MapInto(dst, a, func(v *float64) { *v = -*v })
+	assert.Equal(t, []float64{-1, -2, -3, -4}, dst.vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4}, a.vals, "a should not change")
+
+	big := rangeMatf64(4, 4)
+	v := big.View(1, 3, 1, 3)
+	AddInto(v, a, 1.0)
+	assert.Equal(t, []float64{2, 3, 4, 5}, v.ToSlice1D(), "into a view")
+	assert.Equal(t, 0.0, big.Get(0, 0), "outside the view")
+	d := Matf64FromData([]float64{5, 6, 7, 8}, 2, 2)
+	SubInto(d, a, d.View(0, 1, 0, 2))
+	assert.Equal(t, []float64{-4, -4, -2, -2}, d.vals, "dst overlaps the operand")
+	d = rangeMatf64(3, 3)
+	AddInto(d.View(1, 3, 0, 2), d.View(0, 2, 1, 3), 0.0)
+	assert.Equal(t, []float64{0, 1, 2, 1, 2, 5, 4, 5, 8}, d.vals, "dst overlaps a")
+
+	allocs := testing.AllocsPerRun(10, func() { AddInto(dst, a, a) })
+	assert.Equal(t, 0.0, allocs, "should not allocate")
+
+	err := AddIntoE(Newf64(3, 2), a, b)
+	assert.Equal(t, "AddInto()", err.(*ShapeMismatchError).Op, "should be equal")
+	assert.NotNil(t, MapIntoE(Newf64(1, 4), a, func(*float64) {}), "shapes mismatch")
+
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { MulInto(Newf64(2, 3), a, b) }, "shapes mismatch")
+}
+
+func TestFullf32(t *testing.T) {
+	t.Helper()
+	m := Full[float32](2, 3, 1.5)
+	assert.Equal(t, []float32{1.5, 1.5, 1.5, 1.5, 1.5, 1.5}, m.vals, "should be equal")
+	n := m.Plus(Matf32FromData([]float32{1, 2, 3}))
+	assert.Equal(t, []float32{2.5, 3.5, 4.5, 2.5, 3.5, 4.5}, n.vals, "should be equal")
+	_, err := Matf32FromData([]float32{1, 2, 3}).PlusE(m)
+	assert.NotNil(t, err, "receiver is not broadcast")
+	_, err = FullE[float32](-1, 3, 1.5)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+}
@@ -0,0 +1,215 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+Cholesky is the Cholesky factorization of a symmetric positive definite
+matrix A, such that A = L * transpose(L), where L is lower triangular with a
+positive diagonal. It is created with the Cholesky method of Matf64 or
+Matf32, and can be kept up to date with Update and Downdate when A changes
+by a rank one term, which is much cheaper than factorizing A again:
+
+	c, err := cov.CholeskyE()
+	if err != nil {
+		// cov is not positive definite.
+	}
+	x := c.Solve(b)
+*/
+type Cholesky[T Float] struct {
+	l *Mat[T]
+}
+
+/*
+Cholesky computes the Cholesky factorization of a symmetric positive
+definite matrix. The receiver is not modified.
+*/
+func (m *Mat[T]) Cholesky() *Cholesky[T] {
+	c, err := m.CholeskyE()
+	if err != nil {
+		handleErr(err)
+	}
+	return c
+}
+
+/*
+CholeskyE is the same as Cholesky, except that it returns an error instead
+of exiting the program. A *NotPositiveDefiniteError is returned if the
+matrix is not positive definite, and an *ArgumentError is returned if it is
+not symmetric.
+*/
+func (m *Mat[T]) CholeskyE() (*Cholesky[T], error) {
+	if m.r != m.c {
+		return nil, &ShapeMismatchError{
+			Op:   "Cholesky()",
+			Want: []int{m.r, m.r},
+			Got:  []int{m.r, m.c},
+		}
+	}
+	n := m.r
+	if !m.isSymmetric() {
+		return nil, &ArgumentError{Op: "Cholesky()", Msg: "the matrix is not symmetric"}
+	}
+	l := New[T](n, n)
+	for j := 0; j < n; j++ {
+		d := m.vals[j*m.stride+j]
+		for k := 0; k < j; k++ {
+			d -= l.vals[j*n+k] * l.vals[j*n+k]
+		}
+		if !(d > 0) {
+			return nil, &NotPositiveDefiniteError{Op: "Cholesky()", Col: j}
+		}
+		ljj := T(math.Sqrt(float64(d)))
+		l.vals[j*n+j] = ljj
+		for i := j + 1; i < n; i++ {
+			s := m.vals[i*m.stride+j]
+			for k := 0; k < j; k++ {
+				s -= l.vals[i*n+k] * l.vals[j*n+k]
+			}
+			l.vals[i*n+j] = s / ljj
+		}
+	}
+	return &Cholesky[T]{l}, nil
+}
+
+/*
+L returns a copy of the lower triangular factor.
+*/
+func (c *Cholesky[T]) L() *Mat[T] {
+	return c.l.Copy()
+}
+
+/*
+LogDet returns the natural logarithm of the determinant of the factorized
+matrix, which is always positive.
+*/
+func (c *Cholesky[T]) LogDet() float64 {
+	n := c.l.r
+	var logDet float64
+	for i := 0; i < n; i++ {
+		logDet += math.Log(float64(c.l.vals[i*n+i]))
+	}
+	return 2 * logDet
+}
+
+/*
+Solve returns the solution x of A * x = b, where A is the factorized matrix,
+and b has as many rows as A. Each column of x is the solution for the
+corresponding column of b.
+*/
+func (c *Cholesky[T]) Solve(b *Mat[T]) *Mat[T] {
+	x, err := c.SolveE(b)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+SolveE is the same as Solve, except that it returns an error instead of
+exiting the program.
+*/
+func (c *Cholesky[T]) SolveE(b *Mat[T]) (*Mat[T], error) {
+	n := c.l.r
+	if b.r != n {
+		return nil, &ShapeMismatchError{Op: "Solve()", Want: []int{n, b.c}, Got: []int{b.r, b.c}}
+	}
+	l := c.l.vals
+	x := b.Copy()
+	for j := 0; j < b.c; j++ {
+		for i := 0; i < n; i++ {
+			s := x.vals[i*b.c+j]
+			for k := 0; k < i; k++ {
+				s -= l[i*n+k] * x.vals[k*b.c+j]
+			}
+			x.vals[i*b.c+j] = s / l[i*n+i]
+		}
+		for i := n - 1; i >= 0; i-- {
+			s := x.vals[i*b.c+j]
+			for k := i + 1; k < n; k++ {
+				s -= l[k*n+i] * x.vals[k*b.c+j]
+			}
+			x.vals[i*b.c+j] = s / l[i*n+i]
+		}
+	}
+	return x, nil
+}
+
+/*
+Inverse returns the inverse of the factorized matrix.
+*/
+func (c *Cholesky[T]) Inverse() *Mat[T] {
+	inv, _ := c.SolveE(I[T](c.l.r))
+	return inv
+}
+
+/*
+Update modifies the factorization in place so that it becomes the
+factorization of A + x * transpose(x), where A is the currently factorized
+matrix and x is a vector with as many values as A has rows.
+*/
+func (c *Cholesky[T]) Update(x []T) *Cholesky[T] {
+	if err := c.UpdateE(x); err != nil {
+		handleErr(err)
+	}
+	return c
+}
+
+/*
+UpdateE is the same as Update, except that it returns an error instead of
+exiting the program.
+*/
+func (c *Cholesky[T]) UpdateE(x []T) error {
+	return c.rankOne("Update()", x, 1)
+}
+
+/*
+Downdate modifies the factorization in place so that it becomes the
+factorization of A - x * transpose(x), where A is the currently factorized
+matrix and x is a vector with as many values as A has rows. If the result
+would not be positive definite, the factorization is left unchanged.
+*/
+func (c *Cholesky[T]) Downdate(x []T) *Cholesky[T] {
+	if err := c.DowndateE(x); err != nil {
+		handleErr(err)
+	}
+	return c
+}
+
+/*
+DowndateE is the same as Downdate, except that it returns an error instead
+of exiting the program. A *NotPositiveDefiniteError is returned if the
+result would not be positive definite.
+*/
+func (c *Cholesky[T]) DowndateE(x []T) error {
+	return c.rankOne("Downdate()", x, -1)
+}
+
+// rankOne updates the factor with sign * x * transpose(x), working on a
+// copy so that a failed downdate leaves c intact.
+func (c *Cholesky[T]) rankOne(op string, x []T, sign T) error {
+	n := c.l.r
+	if len(x) != n {
+		return &ShapeMismatchError{Op: op, Want: []int{n}, Got: []int{len(x)}}
+	}
+	l := c.l.Copy()
+	w := make([]T, n)
+	copy(w, x)
+	for k := 0; k < n; k++ {
+		lkk := l.vals[k*n+k]
+		d := lkk*lkk + sign*w[k]*w[k]
+		if !(d > 0) {
+			return &NotPositiveDefiniteError{Op: op, Col: k}
+		}
+		r := T(math.Sqrt(float64(d)))
+		cs, sn := r/lkk, w[k]/lkk
+		l.vals[k*n+k] = r
+		for i := k + 1; i < n; i++ {
+			l.vals[i*n+k] = (l.vals[i*n+k] + sign*sn*w[i]) / cs
+			w[i] = cs*w[i] - sn*l.vals[i*n+k]
+		}
+	}
+	c.l = l
+	return nil
+}
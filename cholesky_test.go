@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCholeskyf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{4, 12, -16},
+		{12, 37, -43},
+		{-16, -43, 98},
+	})
+	c := m.Cholesky()
+	want := []float64{
+		2, 0, 0,
+		6, 1, 0,
+		-8, 5, 3,
+	}
+	assert.InDeltaSlice(t, want, c.L().vals, 1e-12, "should be equal")
+	assert.InDelta(t, math.Log(36), c.LogDet(), 1e-12, "should be equal")
+
+	b := Matf64FromData([]float64{1, 2, 3}, 3)
+	x := c.Solve(b)
+	assert.InDeltaSlice(t, b.vals, m.Dot(x).vals, 1e-10, "should be equal")
+	assert.InDeltaSlice(t, I[float64](3).vals, m.Dot(c.Inverse()).vals, 1e-10,
+		"should be identity")
+}
+
+func TestCholeskyErrorsf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matf64FromData([][]float64{
+		{1, 2},
+		{2, 1},
+	})
+	_, err := m.CholeskyE()
+	var e *NotPositiveDefiniteError
+	assert.True(t, errors.As(err, &e), "should not be positive definite")
+	assert.Equal(t, 1, e.Col, "should be equal")
+	_, err = Matf64FromData([][]float64{{2, 1}, {0, 2}}).CholeskyE()
+	var a *ArgumentError
+	assert.True(t, errors.As(err, &a), "should not be symmetric")
+	assert.Panics(t, func() { Newf64(2, 3).Cholesky() }, "not square")
+	assert.Panics(t, func() { I[float64](2).Cholesky().Solve(Newf64(3, 1)) }, "wrong shape")
+}
+
+func TestCholeskyUpdatef64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matf64FromData([][]float64{
+		{4, 12, -16},
+		{12, 37, -43},
+		{-16, -43, 98},
+	})
+	x := []float64{1, -2, 0.5}
+	xm := Matf64FromData(x, 3)
+	up := m.Copy().Add(xm.Dot(xm.T()))
+	c := m.Cholesky().Update(x)
+	assert.InDeltaSlice(t, up.Cholesky().L().vals, c.L().vals, 1e-12, "should be equal")
+	c.Downdate(x)
+	assert.InDeltaSlice(t, m.Cholesky().L().vals, c.L().vals, 1e-12, "should be equal")
+
+	id := I[float64](2).Cholesky()
+	err := id.DowndateE([]float64{2, 0})
+	var e *NotPositiveDefiniteError
+	assert.True(t, errors.As(err, &e), "should not be positive definite")
+	assert.Equal(t, I[float64](2).vals, id.L().vals, "should be unchanged")
+	assert.Panics(t, func() { id.Update([]float64{1}) }, "wrong length")
+}
+
+func TestCholeskyf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{4, 2},
+		{2, 3},
+	})
+	c := m.Cholesky()
+	x := c.Solve(Matf32FromData([]float32{6, 5}, 2))
+	assert.InDeltaSlice(t, []float32{1, 1}, x.vals, 1e-5, "should be equal")
+	assert.InDelta(t, math.Log(8), c.LogDet(), 1e-5, "should be equal")
+}
@@ -0,0 +1,988 @@
+package matrix
+
+import (
+	"fmt"
+	"math/cmplx"
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+/*
+Complex is the set of element types which a CMat can hold.
+*/
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+/*
+CMat is the complex counterpart of Mat. Just like Mat, it is a flat slice of
+values along with the number of rows and columns, and it has the same
+methods as Mat, except for those which rely on the ordering of the values,
+such as Min, Max and Std. In addition, it provides the conjugate transpose
+H, and the Real, Imag, Modulus and Phase methods which extract the parts of
+the values into a Matf64.
+
+Matc128 and Matc64 are the instances of CMat for complex128 and complex64.
+*/
+type CMat[T Complex] struct {
+	r, c int
+	vals []T
+}
+
+/*
+Matc128 is the instance of CMat for complex128.
+*/
+type Matc128 = CMat[complex128]
+
+/*
+Matc64 is the instance of CMat for complex64.
+*/
+type Matc64 = CMat[complex64]
+
+/*
+Newc128 is the primary constructor for the "Matc128" object. It is called
+with 0 to 2 integers in the same way as Newf64:
+
+	m := matrix.Newc128(x, y)
+
+m is an x by y matrix of zeros.
+*/
+func Newc128(dims ...int) *Matc128 {
+	m, err := newComplexE[complex128]("Newc128()", dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Newc128E is the same as Newc128, except that it returns an error instead of
+exiting the program.
+*/
+func Newc128E(dims ...int) (*Matc128, error) {
+	return newComplexE[complex128]("Newc128()", dims)
+}
+
+/*
+Newc64 is the primary constructor for the "Matc64" object. It is called with
+0 to 2 integers in the same way as Newf64.
+*/
+func Newc64(dims ...int) *Matc64 {
+	m, err := newComplexE[complex64]("Newc64()", dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Newc64E is the same as Newc64, except that it returns an error instead of
+exiting the program.
+*/
+func Newc64E(dims ...int) (*Matc64, error) {
+	return newComplexE[complex64]("Newc64()", dims)
+}
+
+func newComplexE[T Complex](op string, dims []int) (*CMat[T], error) {
+	switch len(dims) {
+	case 0:
+		return &CMat[T]{0, 0, make([]T, 0)}, nil
+	case 1:
+		if err := checkDims[T](op, dims[0], dims[0]); err != nil {
+			return nil, err
+		}
+		return &CMat[T]{dims[0], dims[0], make([]T, dims[0]*dims[0], 2*dims[0]*dims[0])}, nil
+	case 2:
+		if err := checkDims[T](op, dims[0], dims[1]); err != nil {
+			return nil, err
+		}
+		return &CMat[T]{dims[0], dims[1], make([]T, dims[0]*dims[1], 2*dims[0]*dims[1])}, nil
+	}
+	s := "expected 0 to 2 arguments, but received %d"
+	return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+}
+
+/*
+Matc128FromData creates a Matc128 from a []complex128 or a [][]complex128
+slice. The dimensions are passed in the same way as Matf64FromData. For
+example:
+
+	m := matrix.Matc128FromData([]complex128{1, 1i, -1, -1i}, 2, 2)
+*/
+func Matc128FromData(oneOrTwoDSlice interface{}, dims ...int) *Matc128 {
+	m, err := complexMatFromDataE[complex128]("Matc128FromData()", oneOrTwoDSlice, dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Matc128FromDataE is the same as Matc128FromData, except that it returns an
+error instead of exiting the program.
+*/
+func Matc128FromDataE(oneOrTwoDSlice interface{}, dims ...int) (*Matc128, error) {
+	return complexMatFromDataE[complex128]("Matc128FromData()", oneOrTwoDSlice, dims)
+}
+
+/*
+Matc64FromData creates a Matc64 from a []complex64 or a [][]complex64 slice.
+The dimensions are passed in the same way as Matf64FromData.
+*/
+func Matc64FromData(oneOrTwoDSlice interface{}, dims ...int) *Matc64 {
+	m, err := complexMatFromDataE[complex64]("Matc64FromData()", oneOrTwoDSlice, dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Matc64FromDataE is the same as Matc64FromData, except that it returns an
+error instead of exiting the program.
+*/
+func Matc64FromDataE(oneOrTwoDSlice interface{}, dims ...int) (*Matc64, error) {
+	return complexMatFromDataE[complex64]("Matc64FromData()", oneOrTwoDSlice, dims)
+}
+
+func complexMatFromDataE[T Complex](
+	op string, data interface{}, dims []int,
+) (*CMat[T], error) {
+	var v []T
+	var shape []int
+	switch d := data.(type) {
+	case []T:
+		v = d
+		shape = []int{1, len(d)}
+		if len(dims) == 1 {
+			shape = []int{dims[0], 1}
+		}
+	case [][]T:
+		for i := range d {
+			if len(d[i]) != len(d[0]) {
+				s := "row %d has %d values, but row 0 has %d"
+				return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, i, len(d[i]), len(d[0]))}
+			}
+			v = append(v, d[i]...)
+		}
+		shape = []int{len(d), 0}
+		if len(d) > 0 {
+			shape[1] = len(d[0])
+		}
+		if len(dims) == 1 {
+			shape = []int{dims[0], dims[0]}
+		}
+	default:
+		return nil, &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(d)}
+	}
+	switch len(dims) {
+	case 0, 1:
+	case 2:
+		shape = dims
+	default:
+		s := "expected 0 to 2 ints, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	}
+	if err := checkDims[T](op, shape[0], shape[1]); err != nil {
+		return nil, err
+	}
+	if shape[0]*shape[1] != len(v) {
+		return nil, &ShapeMismatchError{Op: op, Want: shape, Got: []int{len(v)}}
+	}
+	m := &CMat[T]{shape[0], shape[1], make([]T, len(v), 2*len(v))}
+	copy(m.vals, v)
+	return m, nil
+}
+
+/*
+Matc128FromParts creates a Matc128 from two Matf64 of the same shape, holding
+the real and the imaginary parts of the values respectively. This is the
+inverse of the Real and Imag methods.
+*/
+func Matc128FromParts(re, im *Matf64) *Matc128 {
+	m, err := Matc128FromPartsE(re, im)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Matc128FromPartsE is the same as Matc128FromParts, except that it returns an
+error instead of exiting the program.
+*/
+func Matc128FromPartsE(re, im *Matf64) (*Matc128, error) {
+	op := "Matc128FromParts()"
+	if re.r != im.r || re.c != im.c {
+		return nil, &ShapeMismatchError{
+			Op:   op,
+			Want: []int{re.r, re.c},
+			Got:  []int{im.r, im.c},
+		}
+	}
+	m, err := newComplexE[complex128](op, []int{re.r, re.c})
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < m.r; i++ {
+		a, b := re.row(i), im.row(i)
+		for j := range a {
+			m.vals[i*m.c+j] = complex(a[j], b[j])
+		}
+	}
+	return m, nil
+}
+
+/*
+Shape returns the number of rows and columns of a CMat.
+*/
+func (m *CMat[T]) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+Reshape changes the rows and the columns of a CMat as long as the total
+number of values remains constant, in the same way as (*Mat).Reshape.
+*/
+func (m *CMat[T]) Reshape(rows, cols int) *CMat[T] {
+	if err := m.ReshapeE(rows, cols); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReshapeE is the same as Reshape, except that it returns an error instead of
+exiting the program.
+*/
+func (m *CMat[T]) ReshapeE(rows, cols int) error {
+	if rows*cols != m.r*m.c {
+		return &ShapeMismatchError{
+			Op:   "Reshape()",
+			Want: []int{m.r * m.c},
+			Got:  []int{rows * cols},
+		}
+	}
+	m.r, m.c = rows, cols
+	return nil
+}
+
+/*
+ToSlice1D returns the values contained in a CMat as a 1D slice.
+*/
+func (m *CMat[T]) ToSlice1D() []T {
+	s := make([]T, len(m.vals))
+	copy(s, m.vals)
+	return s
+}
+
+/*
+ToSlice2D returns the values of a CMat as a 2D slice.
+*/
+func (m *CMat[T]) ToSlice2D() [][]T {
+	s := make([][]T, m.r)
+	for i := range s {
+		s[i] = make([]T, m.c)
+		copy(s[i], m.vals[i*m.c:(i+1)*m.c])
+	}
+	return s
+}
+
+/*
+Get returns the value stored in the given row and column.
+*/
+func (m *CMat[T]) Get(r, c int) T {
+	return m.vals[r*m.c+c]
+}
+
+/*
+Set sets the value of a CMat at a given row and column to a given value.
+*/
+func (m *CMat[T]) Set(r, c int, val T) *CMat[T] {
+	m.vals[r*m.c+c] = val
+	return m
+}
+
+/*
+SetAll sets all values of a CMat to the passed value.
+*/
+func (m *CMat[T]) SetAll(val T) *CMat[T] {
+	for i := range m.vals {
+		m.vals[i] = val
+	}
+	return m
+}
+
+/*
+Map applies a given function to each element of a CMat, in the same way as
+(*Mat).Map. For example, to take the exponential of each element:
+
+	m.Map(func(i *complex128) {
+		*i = cmplx.Exp(*i)
+	})
+*/
+func (m *CMat[T]) Map(f func(*T)) *CMat[T] {
+	for i := range m.vals {
+		f(&m.vals[i])
+	}
+	return m
+}
+
+/*
+All checks if a supplied function is true for all elements of a CMat.
+*/
+func (m *CMat[T]) All(f func(*T) bool) bool {
+	for i := range m.vals {
+		if !f(&m.vals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Any checks if a supplied function is true for one element of a CMat.
+*/
+func (m *CMat[T]) Any(f func(*T) bool) bool {
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// complexScalar converts a complex or a real number held in an interface{}
+// to T.
+func complexScalar[T Complex](v interface{}) (T, bool) {
+	switch x := v.(type) {
+	case T:
+		return x, true
+	case complex128:
+		return T(x), true
+	case complex64:
+		return T(x), true
+	case float64:
+		return T(complex(x, 0)), true
+	case float32:
+		return T(complex(x, 0)), true
+	case int:
+		return T(complex(float64(x), 0)), true
+	}
+	return 0, false
+}
+
+/*
+SetCol sets all elements in a given column to the passed value(s), in the
+same way as (*Mat).SetCol. The passed value can be a complex or a real
+number, or a slice of the element type. Negative index values are allowed.
+*/
+func (m *CMat[T]) SetCol(col int, valOrSlice interface{}) *CMat[T] {
+	if err := m.SetColE(col, valOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetColE is the same as SetCol, except that it returns an error instead of
+exiting the program.
+*/
+func (m *CMat[T]) SetColE(col int, valOrSlice interface{}) error {
+	if (col >= m.c) || (col < -m.c) {
+		return &IndexOutOfRangeError{Op: "SetCol()", Axis: 1, Index: col, Bound: m.c}
+	}
+	if col < 0 {
+		col += m.c
+	}
+	if val, ok := complexScalar[T](valOrSlice); ok {
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val
+		}
+		return nil
+	}
+	switch val := valOrSlice.(type) {
+	case []T:
+		if len(val) != m.r {
+			return &ShapeMismatchError{Op: "SetCol()", Want: []int{m.r}, Got: []int{len(val)}}
+		}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val[r]
+		}
+	default:
+		return &UnsupportedTypeError{Op: "SetCol()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+/*
+SetRow sets all elements in a given row to the passed value(s), in the same
+way as (*Mat).SetRow. The passed value can be a complex or a real number, or
+a slice of the element type. Negative index values are allowed.
+*/
+func (m *CMat[T]) SetRow(row int, valOrSlice interface{}) *CMat[T] {
+	if err := m.SetRowE(row, valOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetRowE is the same as SetRow, except that it returns an error instead of
+exiting the program.
+*/
+func (m *CMat[T]) SetRowE(row int, valOrSlice interface{}) error {
+	if (row >= m.r) || (row < -m.r) {
+		return &IndexOutOfRangeError{Op: "SetRow()", Axis: 0, Index: row, Bound: m.r}
+	}
+	if row < 0 {
+		row += m.r
+	}
+	if val, ok := complexScalar[T](valOrSlice); ok {
+		for c := 0; c < m.c; c++ {
+			m.vals[row*m.c+c] = val
+		}
+		return nil
+	}
+	switch val := valOrSlice.(type) {
+	case []T:
+		if len(val) != m.c {
+			return &ShapeMismatchError{Op: "SetRow()", Want: []int{m.c}, Got: []int{len(val)}}
+		}
+		copy(m.vals[row*m.c:(row+1)*m.c], val)
+	default:
+		return &UnsupportedTypeError{Op: "SetRow()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+/*
+Col returns a new CMat holding a column of the original CMat. Negative index
+values are allowed.
+*/
+func (m *CMat[T]) Col(x int) *CMat[T] {
+	n, err := m.ColE(x)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+ColE is the same as Col, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) ColE(x int) (*CMat[T], error) {
+	if (x >= m.c) || (x < -m.c) {
+		return nil, &IndexOutOfRangeError{Op: "Col()", Axis: 1, Index: x, Bound: m.c}
+	}
+	if x < 0 {
+		x += m.c
+	}
+	v := &CMat[T]{m.r, 1, make([]T, m.r, 2*m.r)}
+	for r := 0; r < m.r; r++ {
+		v.vals[r] = m.vals[r*m.c+x]
+	}
+	return v, nil
+}
+
+/*
+Row returns a new CMat holding a row of the original CMat. Negative index
+values are allowed.
+*/
+func (m *CMat[T]) Row(x int) *CMat[T] {
+	n, err := m.RowE(x)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+RowE is the same as Row, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) RowE(x int) (*CMat[T], error) {
+	if (x >= m.r) || (x < -m.r) {
+		return nil, &IndexOutOfRangeError{Op: "Row()", Axis: 0, Index: x, Bound: m.r}
+	}
+	if x < 0 {
+		x += m.r
+	}
+	v := &CMat[T]{1, m.c, make([]T, m.c, 2*m.c)}
+	copy(v.vals, m.vals[x*m.c:(x+1)*m.c])
+	return v, nil
+}
+
+/*
+Equals checks to see if two CMat objects have the same shape and the same
+values.
+*/
+func (m *CMat[T]) Equals(n *CMat[T]) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i := range m.vals {
+		if m.vals[i] != n.vals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Copy returns a deep copy of a CMat.
+*/
+func (m *CMat[T]) Copy() *CMat[T] {
+	n := &CMat[T]{m.r, m.c, make([]T, len(m.vals), 2*len(m.vals))}
+	copy(n.vals, m.vals)
+	return n
+}
+
+/*
+T returns the transpose of a CMat as a new CMat. Note that the values are
+not conjugated, see H for the conjugate transpose.
+*/
+func (m *CMat[T]) T() *CMat[T] {
+	n := &CMat[T]{m.c, m.r, make([]T, len(m.vals), 2*len(m.vals))}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			n.vals[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	return n
+}
+
+/*
+H returns the conjugate transpose (also known as the Hermitian transpose) of
+a CMat as a new CMat. The value at row x and column y of the result is the
+complex conjugate of the value at row y and column x of m.
+*/
+func (m *CMat[T]) H() *CMat[T] {
+	n := m.T()
+	for i := range n.vals {
+		n.vals[i] = conj(n.vals[i])
+	}
+	return n
+}
+
+/*
+Conj takes the complex conjugate of each element of the receiver, in place.
+*/
+func (m *CMat[T]) Conj() *CMat[T] {
+	for i := range m.vals {
+		m.vals[i] = conj(m.vals[i])
+	}
+	return m
+}
+
+func conj[T Complex](x T) T {
+	return T(cmplx.Conj(complex128(x)))
+}
+
+/*
+Real returns a Matf64 with the same shape as m, holding the real parts of the
+values of m.
+*/
+func (m *CMat[T]) Real() *Matf64 {
+	return m.toMatf64(func(x complex128) float64 { return real(x) })
+}
+
+/*
+Imag returns a Matf64 with the same shape as m, holding the imaginary parts
+of the values of m.
+*/
+func (m *CMat[T]) Imag() *Matf64 {
+	return m.toMatf64(func(x complex128) float64 { return imag(x) })
+}
+
+/*
+Modulus returns a Matf64 with the same shape as m, holding the moduli (or
+absolute values) of the values of m. Unlike (*Mat).Abs, it does not modify
+the receiver.
+*/
+func (m *CMat[T]) Modulus() *Matf64 {
+	return m.toMatf64(cmplx.Abs)
+}
+
+/*
+Phase returns a Matf64 with the same shape as m, holding the phases (or
+arguments) of the values of m, in the range [-Pi, Pi].
+*/
+func (m *CMat[T]) Phase() *Matf64 {
+	return m.toMatf64(cmplx.Phase)
+}
+
+func (m *CMat[T]) toMatf64(f func(complex128) float64) *Matf64 {
+	n := Newf64(m.r, m.c)
+	for i := range m.vals {
+		n.vals[i] = f(complex128(m.vals[i]))
+	}
+	return n
+}
+
+/*
+Mul multiplies each element of the receiver by the passed value, which can be
+a complex or a real number, or a CMat of the same shape as the receiver.
+*/
+func (m *CMat[T]) Mul(valOrMat interface{}) *CMat[T] {
+	if err := m.MulE(valOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+MulE is the same as Mul, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) MulE(valOrMat interface{}) error {
+	return m.apply("Mul()", valOrMat, func(a, b T) T { return a * b })
+}
+
+/*
+Add adds the passed value to each element of the receiver. The passed value
+can be a complex or a real number, or a CMat of the same shape as the
+receiver.
+*/
+func (m *CMat[T]) Add(valOrMat interface{}) *CMat[T] {
+	if err := m.AddE(valOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AddE is the same as Add, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) AddE(valOrMat interface{}) error {
+	return m.apply("Add()", valOrMat, func(a, b T) T { return a + b })
+}
+
+/*
+Sub subtracts the passed value from each element of the receiver. The passed
+value can be a complex or a real number, or a CMat of the same shape as the
+receiver.
+*/
+func (m *CMat[T]) Sub(valOrMat interface{}) *CMat[T] {
+	if err := m.SubE(valOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SubE is the same as Sub, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) SubE(valOrMat interface{}) error {
+	return m.apply("Sub()", valOrMat, func(a, b T) T { return a - b })
+}
+
+/*
+Div divides each element of the receiver by the passed value. The passed
+value can be a complex or a real number, or a CMat of the same shape as the
+receiver.
+*/
+func (m *CMat[T]) Div(valOrMat interface{}) *CMat[T] {
+	if err := m.DivE(valOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+DivE is the same as Div, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) DivE(valOrMat interface{}) error {
+	return m.apply("Div()", valOrMat, func(a, b T) T { return a / b })
+}
+
+func (m *CMat[T]) apply(op string, valOrMat interface{}, f func(a, b T) T) error {
+	if x, ok := complexScalar[T](valOrMat); ok {
+		for i := range m.vals {
+			m.vals[i] = f(m.vals[i], x)
+		}
+		return nil
+	}
+	switch v := valOrMat.(type) {
+	case *CMat[T]:
+		if v.r != m.r || v.c != m.c {
+			return &ShapeMismatchError{Op: op, Want: []int{m.r, m.c}, Got: []int{v.r, v.c}}
+		}
+		for i := range m.vals {
+			m.vals[i] = f(m.vals[i], v.vals[i])
+		}
+	default:
+		return &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(v)}
+	}
+	return nil
+}
+
+/*
+Sum returns the sum of the elements of a CMat. It is called in the same way
+as (*Mat).Sum:
+
+	m.Sum()     // The sum of all elements in m
+	m.Sum(0, 2) // The sum of the 3rd row
+	m.Sum(1, 0) // The sum of the first column
+*/
+func (m *CMat[T]) Sum(args ...int) T {
+	x, err := m.SumE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+SumE is the same as Sum, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) SumE(args ...int) (T, error) {
+	x, _, err := m.fold("Sum()", 0, func(a, b T) T { return a + b }, args)
+	return x, err
+}
+
+/*
+Avg returns the average of the elements of a CMat. It is called in the same
+way as Sum.
+*/
+func (m *CMat[T]) Avg(args ...int) T {
+	x, err := m.AvgE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+AvgE is the same as Avg, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) AvgE(args ...int) (T, error) {
+	x, n, err := m.fold("Avg()", 0, func(a, b T) T { return a + b }, args)
+	if err != nil {
+		return 0, err
+	}
+	return x / T(complex(float64(n), 0)), nil
+}
+
+/*
+Prd returns the product of the elements of a CMat. It is called in the same
+way as Sum.
+*/
+func (m *CMat[T]) Prd(args ...int) T {
+	x, err := m.PrdE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+PrdE is the same as Prd, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) PrdE(args ...int) (T, error) {
+	x, _, err := m.fold("Prd()", 1, func(a, b T) T { return a * b }, args)
+	return x, err
+}
+
+// fold combines the elements of m, or of one of its rows or columns, with f,
+// starting from init. It also returns the number of combined elements.
+func (m *CMat[T]) fold(op string, init T, f func(a, b T) T, args []int) (T, int, error) {
+	acc := init
+	start, stride, n := 0, 1, len(m.vals)
+	switch len(args) {
+	case 0:
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: op, Axis: 0, Index: slice, Bound: m.r}
+			}
+			start, stride, n = slice*m.c, 1, m.c
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: op, Axis: 1, Index: slice, Bound: m.c}
+			}
+			start, stride, n = slice, m.c, m.r
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(args))}
+	}
+	for i := 0; i < n; i++ {
+		acc = f(acc, m.vals[start+i*stride])
+	}
+	return acc, n, nil
+}
+
+/*
+Dot is the matrix multiplication of two CMat objects, defined in the same
+way as (*Mat).Dot. Note that the values of neither CMat are conjugated; to
+compute the inner product of two column vectors u and v, use:
+
+	u.H().Dot(v)
+*/
+func (m *CMat[T]) Dot(n *CMat[T]) *CMat[T] {
+	o, err := m.DotE(n)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+DotE is the same as Dot, except that it returns an error instead of exiting
+the program.
+*/
+func (m *CMat[T]) DotE(n *CMat[T]) (*CMat[T], error) {
+	if m.c != n.r {
+		return nil, &ShapeMismatchError{Op: "Dot()", Want: []int{m.c, n.c}, Got: []int{n.r, n.c}}
+	}
+	o := &CMat[T]{m.r, n.c, make([]T, m.r*n.c, 2*m.r*n.c)}
+	for i := 0; i < m.r; i++ {
+		for k := 0; k < m.c; k++ {
+			a := m.vals[i*m.c+k]
+			for j := 0; j < n.c; j++ {
+				o.vals[i*o.c+j] += a * n.vals[k*n.c+j]
+			}
+		}
+	}
+	return o, nil
+}
+
+/*
+AppendCol appends a column to the right side of a CMat.
+*/
+func (m *CMat[T]) AppendCol(v []T) *CMat[T] {
+	if err := m.AppendColE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendColE is the same as AppendCol, except that it returns an error instead
+of exiting the program.
+*/
+func (m *CMat[T]) AppendColE(v []T) error {
+	if m.r != len(v) {
+		return &ShapeMismatchError{Op: "AppendCol()", Want: []int{m.r}, Got: []int{len(v)}}
+	}
+	return m.concat("AppendCol()", &CMat[T]{len(v), 1, v})
+}
+
+/*
+AppendRow appends a row to the bottom of a CMat.
+*/
+func (m *CMat[T]) AppendRow(v []T) *CMat[T] {
+	if err := m.AppendRowE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendRowE is the same as AppendRow, except that it returns an error instead
+of exiting the program.
+*/
+func (m *CMat[T]) AppendRowE(v []T) error {
+	if m.c != len(v) {
+		return &ShapeMismatchError{Op: "AppendRow()", Want: []int{m.c}, Got: []int{len(v)}}
+	}
+	m.vals = append(m.vals, v...)
+	m.r++
+	return nil
+}
+
+/*
+Concat merges a passed CMat to the right side of the receiver. The passed
+CMat must have the same number of rows as the receiver.
+*/
+func (m *CMat[T]) Concat(n *CMat[T]) *CMat[T] {
+	if err := m.ConcatE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ConcatE is the same as Concat, except that it returns an error instead of
+exiting the program.
+*/
+func (m *CMat[T]) ConcatE(n *CMat[T]) error {
+	return m.concat("Concat()", n)
+}
+
+func (m *CMat[T]) concat(op string, n *CMat[T]) error {
+	if m.r != n.r {
+		return &ShapeMismatchError{Op: op, Want: []int{m.r, n.c}, Got: []int{n.r, n.c}}
+	}
+	c := m.c + n.c
+	vals := make([]T, m.r*c, 2*m.r*c)
+	for i := 0; i < m.r; i++ {
+		copy(vals[i*c:], m.vals[i*m.c:(i+1)*m.c])
+		copy(vals[i*c+m.c:], n.vals[i*n.c:(i+1)*n.c])
+	}
+	m.c, m.vals = c, vals
+	return nil
+}
+
+/*
+Append merges a passed CMat to the bottom of the receiver. The passed CMat
+must have the same number of columns as the receiver.
+*/
+func (m *CMat[T]) Append(n *CMat[T]) *CMat[T] {
+	if err := m.AppendE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendE is the same as Append, except that it returns an error instead of
+exiting the program.
+*/
+func (m *CMat[T]) AppendE(n *CMat[T]) error {
+	if m.c != n.c {
+		return &ShapeMismatchError{Op: "Append()", Want: []int{n.r, m.c}, Got: []int{n.r, n.c}}
+	}
+	m.vals = append(m.vals, n.vals...)
+	m.r += n.r
+	return nil
+}
+
+/*
+String returns the string representation of a CMat, in the same format as
+(*Mat).String.
+*/
+func (m *CMat[T]) String() string {
+	var x T
+	bits := int(unsafe.Sizeof(x)) * 8
+	str := "["
+	for i := 0; i < m.r; i++ {
+		str += "["
+		for j := 0; j < m.c; j++ {
+			str += strconv.FormatComplex(complex128(m.vals[i*m.c+j]), 'f', 14, bits)
+			if j+1 != m.c {
+				str += ",\t"
+			}
+		}
+		str += "]"
+		if i+1 != m.r {
+			str += "\n "
+		}
+	}
+	str += "]\n"
+	return str
+}
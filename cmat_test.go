@@ -0,0 +1,119 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewc128(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Newc128(3, 4)
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 4, m.c, "should be equal")
+	assert.Equal(t, 12, len(m.vals), "should be equal")
+	n := Newc64(5)
+	assert.Equal(t, 25, len(n.vals), "should be equal")
+	assert.Panics(t, func() { Newc128(1, 2, 3) }, "should panic with 3+ args")
+	_, err := Newc128E(-1)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newc64E(2, -3)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newc128E(100000000000, 100000000000)
+	assert.IsType(t, &ArgumentError{}, err, "too large")
+}
+
+func TestMatc128FromData(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matc128FromData([]complex128{1, 1i, -1, -1i}, 2, 2)
+	assert.Equal(t, -1i, m.Get(1, 1), "should be equal")
+	m = Matc128FromData([][]complex128{{1, 2i}, {3, 4i}})
+	assert.Equal(t, complex128(3), m.Get(1, 0), "should be equal")
+	n := Matc64FromData([]complex64{1, 2, 3}, 3)
+	r, c := n.Shape()
+	assert.Equal(t, 3, r, "should be a column")
+	assert.Equal(t, 1, c, "should be a column")
+	assert.Panics(t, func() { Matc128FromData([]complex128{1, 2, 3}, 2, 2) }, "wrong size")
+	assert.Panics(t, func() { Matc128FromData([]float64{1}) }, "wrong type")
+	_, err := Matc128FromDataE([]complex128{1, 2, 3, 4}, -2, -2)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimensions")
+	_, err = Matc128FromDataE([][]complex128{{1}, {2, 3}, {}})
+	assert.IsType(t, &ArgumentError{}, err, "rows of unequal length")
+
+	re := Matf64FromData([]float64{1, 2, 3, 4}, 2, 2)
+	im := Matf64FromData([]float64{5, 6, 7, 8}, 2, 2)
+	p := Matc128FromParts(re, im)
+	assert.True(t, p.Real().Equals(re), "should be equal")
+	assert.True(t, p.Imag().Equals(im), "should be equal")
+	big := Matf64FromData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	p = Matc128FromParts(big.View(1, 3, 1, 3), big.View(0, 2, 0, 2))
+	assert.Equal(t, []complex128{5 + 1i, 6 + 2i, 8 + 4i, 9 + 5i}, p.vals, "views")
+}
+
+func TestConjTransposec128(t *testing.T) {
+	t.Helper()
+	m := Matc128FromData([]complex128{1 + 1i, 2, 3 - 2i, 4i, 5, 6}, 2, 3)
+	h := m.H()
+	assert.Equal(t, []complex128{1 - 1i, -4i, 2, 5, 3 + 2i, 6}, h.vals, "should be equal")
+	assert.Equal(t, []complex128{1 + 1i, 4i, 2, 5, 3 - 2i, 6}, m.T().vals, "should be equal")
+	assert.True(t, h.H().Equals(m), "should be equal")
+	n := Matc64FromData([]complex64{1 + 1i, 2 - 3i})
+	n.Conj()
+	assert.Equal(t, []complex64{1 - 1i, 2 + 3i}, n.vals, "should be equal")
+}
+
+func TestModulusPhasec128(t *testing.T) {
+	t.Helper()
+	m := Matc128FromData([]complex128{3 + 4i, -1, 1i, 0})
+	assert.Equal(t, []float64{5, 1, 1, 0}, m.Modulus().vals, "should be equal")
+	assert.Equal(t, []float64{3, -1, 0, 0}, m.Real().vals, "should be equal")
+	assert.Equal(t, []float64{4, 0, 1, 0}, m.Imag().vals, "should be equal")
+	ph := m.Phase()
+	assert.InDelta(t, math.Pi, ph.Get(0, 1), 1e-14, "should be equal")
+	assert.InDelta(t, math.Pi/2, ph.Get(0, 2), 1e-14, "should be equal")
+}
+
+func TestArithc128(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matc128FromData([]complex128{1, 1i, -1, -1i}, 2, 2)
+	m.Mul(1i)
+	assert.Equal(t, []complex128{1i, -1, -1i, 1}, m.vals, "should be equal")
+	m.Add(2.0).Sub(Matc128FromData([]complex128{1i, -1, -1i, 1}, 2, 2)).Div(2)
+	assert.Equal(t, []complex128{1, 1, 1, 1}, m.vals, "should be equal")
+	m.SetRow(0, []complex128{1i, 2i}).SetCol(-1, 3)
+	assert.Equal(t, []complex128{1i, 3, 1, 3}, m.vals, "should be equal")
+	assert.Equal(t, 1+1i, m.Sum(1, 0), "should be equal")
+	assert.Equal(t, 1.75+0.25i, m.Avg(), "should be equal")
+	assert.Equal(t, 9i, m.Prd(), "should be equal")
+	assert.NotNil(t, m.AddE(Newc128(3)), "wrong shape")
+	assert.NotNil(t, m.MulE("a"), "wrong type")
+	assert.Panics(t, func() { m.Sum(2, 0) }, "wrong axis")
+}
+
+func TestDotc128(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matc128FromData([]complex128{1, 1i, 2, -1i}, 2, 2)
+	n := Matc128FromData([]complex128{1i, 1, 1, 0}, 2, 2)
+	assert.Equal(t, []complex128{2i, 1, -1i + 2i, 2}, m.Dot(n).vals, "should be equal")
+	u := Matc128FromData([]complex128{1 + 1i, 2i}, 2)
+	assert.Equal(t, complex128(6), u.H().Dot(u).Get(0, 0), "norm squared")
+	assert.Panics(t, func() { m.Dot(u.T()) }, "wrong shape")
+}
+
+func TestAppendConcatc128(t *testing.T) {
+	t.Helper()
+	m := Matc128FromData([]complex128{1, 2, 3, 4}, 2, 2)
+	m.AppendCol([]complex128{5i, 6i}).AppendRow([]complex128{7, 8, 9})
+	assert.Equal(t, []complex128{1, 2, 5i, 3, 4, 6i, 7, 8, 9}, m.vals, "should be equal")
+	m.Concat(Newc128(3, 1)).Append(Newc128(1, 4))
+	r, c := m.Shape()
+	assert.Equal(t, 4, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, "[[(1.00000000000000+2.00000000000000i)]]\n",
+		Matc128FromData([]complex128{1 + 2i}).String(), "should be equal")
+}
@@ -0,0 +1,333 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+MissingPolicy sets what ReadCSV does with an empty or NA cell.
+*/
+type MissingPolicy int
+
+const (
+	// MissingError makes ReadCSV return a ParseError for a missing value.
+	MissingError MissingPolicy = iota
+	// MissingNaN makes ReadCSV store NaN in place of a missing value.
+	MissingNaN
+	// MissingFill makes ReadCSV store CSVOptions.Fill in place of a missing
+	// value.
+	MissingFill
+)
+
+/*
+CSVOptions configures how ReadCSV parses its input. The zero value reads
+comma separated values with no header and no comments, keeps all the
+columns, and treats missing values as an error, which is how Matf64FromCSV
+reads a file.
+*/
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ',' when it is zero.
+	Comma rune
+	// Comment, if not zero, is the character which starts a comment line.
+	// Comment lines are skipped, as are empty lines.
+	Comment rune
+	// Header is true when the first line holds the names of the columns.
+	Header bool
+	// Cols holds the indices of the columns to read, in the order that they
+	// are stored in the returned Mat. Negative indices count from the last
+	// column. All the columns are read when Cols is empty.
+	Cols []int
+	// Missing sets what is done with the cells which are empty, or hold one
+	// of the strings in NA, once their surrounding spaces are removed.
+	Missing MissingPolicy
+	// Fill is the value of the missing cells when Missing is MissingFill.
+	Fill float64
+	// NA holds the strings which stand for a missing value, other than the
+	// empty string. If NA is nil, "NA" and "N/A" are used.
+	NA []string
+}
+
+/*
+ReadCSVf64 reads a Matf64 from comma separated values, as configured by the
+passed options, which can be nil to use the defaults. Each line becomes a row,
+and all lines must hold the same number of values. An input with no lines is
+an error. When opts.Header is true, the names of the read columns are
+returned as well. For example:
+
+	f, _ := os.Open("data.tsv")
+	defer f.Close()
+	m, names := matrix.ReadCSVf64(f, &matrix.CSVOptions{
+		Comma:   '\t',
+		Header:  true,
+		Cols:    []int{0, 2},
+		Missing: matrix.MissingNaN,
+	})
+
+The input is read one line at a time, so it need not be a file; any
+io.Reader such as a strings.Reader or a network connection will do.
+*/
+func ReadCSVf64(r io.Reader, opts *CSVOptions) (*Matf64, []string) {
+	m, header, err := readCSVE[float64]("ReadCSVf64()", r, opts)
+	if err != nil {
+		handleErr(err)
+	}
+	return m, header
+}
+
+/*
+ReadCSVf64E is the same as ReadCSVf64, except that it returns an error
+instead of exiting the program.
+*/
+func ReadCSVf64E(r io.Reader, opts *CSVOptions) (*Matf64, []string, error) {
+	return readCSVE[float64]("ReadCSVf64()", r, opts)
+}
+
+/*
+ReadCSVf32 is the same as ReadCSVf64, but returns a Matf32.
+*/
+func ReadCSVf32(r io.Reader, opts *CSVOptions) (*Matf32, []string) {
+	m, header, err := readCSVE[float32]("ReadCSVf32()", r, opts)
+	if err != nil {
+		handleErr(err)
+	}
+	return m, header
+}
+
+/*
+ReadCSVf32E is the same as ReadCSVf32, except that it returns an error
+instead of exiting the program.
+*/
+func ReadCSVf32E(r io.Reader, opts *CSVOptions) (*Matf32, []string, error) {
+	return readCSVE[float32]("ReadCSVf32()", r, opts)
+}
+
+/*
+ReadCSV is the generic counterpart of ReadCSVf64 and ReadCSVf32.
+*/
+func ReadCSV[T Float](r io.Reader, opts *CSVOptions) (*Mat[T], []string) {
+	m, header, err := readCSVE[T]("ReadCSV()", r, opts)
+	if err != nil {
+		handleErr(err)
+	}
+	return m, header
+}
+
+/*
+ReadCSVE is the same as ReadCSV, except that it returns an error instead of
+exiting the program.
+*/
+func ReadCSVE[T Float](r io.Reader, opts *CSVOptions) (*Mat[T], []string, error) {
+	return readCSVE[T]("ReadCSV()", r, opts)
+}
+
+func readCSVE[T Float](op string, r io.Reader, opts *CSVOptions) (*Mat[T], []string, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.Comment = opts.Comment
+	cr.ReuseRecord = true
+	na := opts.NA
+	if na == nil {
+		na = []string{"NA", "N/A"}
+	}
+	var fill T
+	switch opts.Missing {
+	case MissingError:
+	case MissingNaN:
+		fill = T(math.NaN())
+	case MissingFill:
+		fill = T(opts.Fill)
+	default:
+		s := "unknown missing value policy %d"
+		return nil, nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, opts.Missing)}
+	}
+
+	str, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, &ArgumentError{Op: op, Msg: "the input is empty"}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	cols, err := resolveIndices(op, 1, opts.Cols, len(str))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(opts.Cols) == 0 {
+		cols = make([]int, len(str))
+		for i := range cols {
+			cols[i] = i
+		}
+	}
+	var header []string
+	m := New[T]()
+	m.c, m.stride = len(cols), len(cols)
+	if opts.Header {
+		header = make([]string, len(cols))
+		for i, c := range cols {
+			header[i] = strings.TrimSpace(str[c])
+		}
+		str, err = cr.Read()
+	}
+	for err == nil {
+		for _, c := range cols {
+			s := strings.TrimSpace(str[c])
+			if opts.Missing != MissingError && isNA(s, na) {
+				m.vals = append(m.vals, fill)
+				continue
+			}
+			x, perr := strconv.ParseFloat(s, bitSize[T]())
+			if perr != nil {
+				line, _ := cr.FieldPos(c)
+				return nil, nil, &ParseError{Op: op, Line: line, Item: c, Value: s, Err: perr}
+			}
+			m.vals = append(m.vals, T(x))
+		}
+		m.r++
+		str, err = cr.Read()
+	}
+	if err != io.EOF {
+		return nil, nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	return m, header, nil
+}
+
+// isNA reports whether s stands for a missing value.
+func isNA(s string, na []string) bool {
+	if s == "" {
+		return true
+	}
+	for _, v := range na {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+CSVWriteOptions configures how WriteCSV formats a Mat. The zero value writes
+comma separated values with no header, using the shortest representation of
+each value which reads back to exactly the same number.
+*/
+type CSVWriteOptions struct {
+	// Comma is the field delimiter. It defaults to ',' when it is zero.
+	Comma rune
+	// Header, if not nil, is written as the first line. It must hold one
+	// name for each column.
+	Header []string
+	// Format and Prec are passed to strconv.FormatFloat, so that Format 'f'
+	// with Prec 3 writes 3 digits after the decimal point. When Format is
+	// zero, the shortest exact representation is used and Prec is ignored.
+	Format byte
+	Prec   int
+}
+
+/*
+WriteCSV writes the values of a Mat to the passed io.Writer as comma
+separated values, one line per row, as configured by the passed options,
+which can be nil to use the defaults. For example:
+
+	m.WriteCSV(os.Stdout, &matrix.CSVWriteOptions{
+		Comma:  '\t',
+		Header: []string{"x", "y"},
+		Format: 'f',
+		Prec:   3,
+	})
+
+The output is buffered and written one row at a time, so that large matrices
+can be streamed to a file or a network connection.
+*/
+func (m *Mat[T]) WriteCSV(w io.Writer, opts *CSVWriteOptions) {
+	if err := m.WriteCSVE(w, opts); err != nil {
+		handleErr(err)
+	}
+}
+
+/*
+WriteCSVE is the same as WriteCSV, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) WriteCSVE(w io.Writer, opts *CSVWriteOptions) error {
+	return m.writeCSV("WriteCSV()", w, opts)
+}
+
+func (m *Mat[T]) writeCSV(op string, w io.Writer, opts *CSVWriteOptions) error {
+	if opts == nil {
+		opts = &CSVWriteOptions{}
+	}
+	format, prec := opts.Format, opts.Prec
+	switch format {
+	case 0:
+		format, prec = 'g', -1
+	case 'b', 'e', 'E', 'f', 'g', 'G', 'x', 'X':
+	default:
+		s := "%q is not a valid float format"
+		return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, format)}
+	}
+	if opts.Header != nil && len(opts.Header) != m.c {
+		return &ShapeMismatchError{Op: op, Want: []int{m.c}, Got: []int{len(opts.Header)}}
+	}
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if opts.Header != nil {
+		if err := cw.Write(opts.Header); err != nil {
+			return fmt.Errorf("In %s, %w", op, err)
+		}
+	}
+	record := make([]string, m.c)
+	var buf []byte
+	for i := 0; i < m.r; i++ {
+		for j, v := range m.row(i) {
+			buf = strconv.AppendFloat(buf[:0], float64(v), format, prec, bitSize[T]())
+			record[j] = string(buf)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("In %s, %w", op, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("In %s, %w", op, err)
+	}
+	return nil
+}
+
+// noFinalNewline passes its writes on to w, except for a newline at the end
+// of a write, which is held back until more output follows it. It drops the
+// newline which encoding/csv writes after the last record.
+type noFinalNewline struct {
+	w       io.Writer
+	pending bool
+}
+
+func (n *noFinalNewline) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if n.pending {
+		if _, err := n.w.Write([]byte{'\n'}); err != nil {
+			return 0, err
+		}
+		n.pending = false
+	}
+	q := p
+	if p[len(p)-1] == '\n' {
+		q, n.pending = p[:len(p)-1], true
+	}
+	if _, err := n.w.Write(q); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,127 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSVf64(t *testing.T) {
+	t.Helper()
+	m, header := ReadCSVf64(strings.NewReader("1,2,3\n4,5,6\n"), nil)
+	assert.Nil(t, header, "no header")
+	assert.Equal(t, [][]float64{{1, 2, 3}, {4, 5, 6}}, m.ToSlice2D(), "should be equal")
+
+	in := "# exported data\nx; y; z\n1; 2; 3\n\n# more\n4; ; 6\n7; NA; 9\n"
+	m, header = ReadCSVf64(strings.NewReader(in), &CSVOptions{
+		Comma:   ';',
+		Comment: '#',
+		Header:  true,
+		Missing: MissingFill,
+		Fill:    -1,
+	})
+	assert.Equal(t, []string{"x", "y", "z"}, header, "should be equal")
+	assert.Equal(t, [][]float64{{1, 2, 3}, {4, -1, 6}, {7, -1, 9}}, m.ToSlice2D(),
+		"should be equal")
+
+	m, header = ReadCSVf64(strings.NewReader("a\tb\tc\n1\t?\t3\n"), &CSVOptions{
+		Comma:   '\t',
+		Header:  true,
+		Cols:    []int{-1, 1},
+		Missing: MissingNaN,
+		NA:      []string{"?"},
+	})
+	assert.Equal(t, []string{"c", "b"}, header, "selected columns")
+	assert.Equal(t, 3.0, m.Get(0, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 1)), "missing value")
+
+	m, header = ReadCSVf64(strings.NewReader("a,b\n"), &CSVOptions{Header: true})
+	r, c := m.Shape()
+	assert.Equal(t, []int{0, 2}, []int{r, c}, "header only")
+	assert.Equal(t, []string{"a", "b"}, header, "header only")
+}
+
+func TestReadCSVErrorsf64(t *testing.T) {
+	t.Helper()
+	_, _, err := ReadCSVf64E(strings.NewReader("1,2\n3,\n"), nil)
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr), "missing values are an error by default")
+	assert.Equal(t, 2, perr.Line, "should be equal")
+	assert.Equal(t, 1, perr.Item, "should be equal")
+
+	_, _, err = ReadCSVf64E(strings.NewReader("# c\n1,2\n3,x\n"), &CSVOptions{Comment: '#'})
+	assert.True(t, errors.As(err, &perr), "not a number")
+	assert.Equal(t, 3, perr.Line, "comment lines are counted")
+
+	_, _, err = ReadCSVf64E(strings.NewReader("1,2\n3\n"), nil)
+	assert.NotNil(t, err, "wrong number of fields")
+	_, _, err = ReadCSVf64E(strings.NewReader(""), nil)
+	assert.IsType(t, &ArgumentError{}, err, "empty input")
+	_, _, err = ReadCSVf64E(strings.NewReader("# only a comment\n"), &CSVOptions{Comment: '#'})
+	assert.IsType(t, &ArgumentError{}, err, "no lines but comments")
+	_, _, err = ReadCSVf64E(strings.NewReader("1,2\n"), &CSVOptions{Cols: []int{2}})
+	assert.NotNil(t, err, "column out of range")
+	_, _, err = ReadCSVf64E(strings.NewReader("1,2\n"), &CSVOptions{Missing: 7})
+	assert.NotNil(t, err, "unknown policy")
+
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { ReadCSVf64(strings.NewReader("a,b\n"), nil) }, "header")
+}
+
+func TestReadCSVf32(t *testing.T) {
+	t.Helper()
+	m, _ := ReadCSVf32(strings.NewReader("1.5|2\n3|4\n"), &CSVOptions{Comma: '|'})
+	assert.Equal(t, []float32{1.5, 2, 3, 4}, m.vals, "should be equal")
+	_, _, err := ReadCSVf32E(strings.NewReader("1e40\n"), nil)
+	assert.NotNil(t, err, "out of the range of float32")
+	n, _ := ReadCSV[float32](strings.NewReader(" 1 , 2 \n"), nil)
+	assert.Equal(t, []float32{1, 2}, n.vals, "spaces are trimmed")
+}
+
+func TestWriteCSVf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 0.1, -2.5},
+		{math.NaN(), 1e21, 3},
+	})
+	var b bytes.Buffer
+	m.WriteCSV(&b, nil)
+	assert.Equal(t, "1,0.1,-2.5\nNaN,1e+21,3\n", b.String(), "should be equal")
+
+	b.Reset()
+	m.View(0, 1, 0, 3).WriteCSV(&b, &CSVWriteOptions{
+		Comma:  ';',
+		Header: []string{"a", "b;c", "d"},
+		Format: 'f',
+		Prec:   2,
+	})
+	assert.Equal(t, "a;\"b;c\";d\n1.00;0.10;-2.50\n", b.String(), "should be equal")
+
+	// What is written reads back to the same values.
+	n := RandMatf64(7, 5)
+	b.Reset()
+	n.WriteCSV(&b, &CSVWriteOptions{Header: []string{"a", "b", "c", "d", "e"}})
+	o, header := ReadCSVf64(&b, &CSVOptions{Header: true})
+	assert.True(t, n.Equals(o), "round trip")
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, header, "round trip")
+
+	assert.NotNil(t, m.WriteCSVE(&b, &CSVWriteOptions{Header: []string{"a"}}), "header length")
+	assert.NotNil(t, m.WriteCSVE(&b, &CSVWriteOptions{Format: 'q'}), "invalid format")
+	assert.NotNil(t, m.WriteCSVE(&b, &CSVWriteOptions{Comma: '\n'}), "invalid delimiter")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.WriteCSV(&b, &CSVWriteOptions{Format: 'q'}) }, "invalid format")
+}
+
+func TestWriteCSVf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([]float32{0.1, 1.0 / 3}, 2, 1)
+	var b bytes.Buffer
+	m.WriteCSV(&b, &CSVWriteOptions{Comma: '\t'})
+	assert.Equal(t, "0.1\n0.33333334\n", b.String(), "shortest float32 representation")
+	n, _ := ReadCSVf32(&b, nil)
+	assert.True(t, m.Equals(n), "round trip")
+}
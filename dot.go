@@ -0,0 +1,200 @@
+package matrix
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chewxy/vecf32"
+	"github.com/chewxy/vecf64"
+)
+
+// The tile sizes of Dot. A tile of dotBlockK rows and dotBlockJ columns of
+// the right hand side, which is reused for every row of a row block, fits in
+// the L2 cache of current processors for both float64 and float32.
+const (
+	dotBlockI = 64
+	dotBlockK = 64
+	dotBlockJ = 256
+)
+
+// dotThreshold is the number of multiply-adds below which Dot runs on the
+// calling goroutine only.
+var dotThreshold int64 = 1 << 18
+
+/*
+SetDotThreshold sets the size of a matrix multiplication, measured as the
+number of multiply-add operations, or r*k*c for an r by k matrix times a k by
+c matrix, at or above which Dot splits the work across multiple goroutines,
+and returns the previous threshold. Smaller products run on the calling
+goroutine, where the cost of starting the workers would outweigh the gain. A
+threshold of 0 always uses the workers, while a negative threshold never
+does. It is safe to call SetDotThreshold from multiple goroutines.
+*/
+func SetDotThreshold(n int) int {
+	return int(atomic.SwapInt64(&dotThreshold, int64(n)))
+}
+
+/*
+Transpose selects whether an argument of Gemm is used as it is, or
+transposed.
+*/
+type Transpose bool
+
+const (
+	// NoTrans uses a matrix as it is.
+	NoTrans Transpose = false
+	// Trans uses the transpose of a matrix.
+	Trans Transpose = true
+)
+
+/*
+Gemm computes alpha * op(a) * op(b) + beta * m, where op(x) is either x or
+its transpose as selected by tA and tB, and stores the result in m, which
+must already have the shape of the product. This is the general matrix
+multiplication of BLAS. Unlike Dot, Gemm does not allocate, which makes it
+suitable for loops that compute many products of the same shape:
+
+	// m = a * transpose(b)
+	m.Gemm(matrix.NoTrans, matrix.Trans, 1, a, b, 0)
+	// m = m + 0.5 * a * b
+	m.Gemm(matrix.NoTrans, matrix.NoTrans, 0.5, a, b, 1)
+
+When beta is 0, the values that m holds beforehand are ignored, even if
+they are NaN. The receiver may be a view, in which case the product is written
+into its parent, but it must not be a or b, or share values with them.
+*/
+func (m *Mat[T]) Gemm(tA, tB Transpose, alpha T, a, b *Mat[T], beta T) *Mat[T] {
+	if err := m.GemmE(tA, tB, alpha, a, b, beta); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+GemmE is the same as Gemm, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) GemmE(tA, tB Transpose, alpha T, a, b *Mat[T], beta T) error {
+	ar, ac := a.r, a.c
+	if tA {
+		ar, ac = ac, ar
+	}
+	br, bc := b.r, b.c
+	if tB {
+		br, bc = bc, br
+	}
+	if ac != br {
+		return &ShapeMismatchError{Op: "Gemm()", Want: []int{ac, bc}, Got: []int{br, bc}}
+	}
+	if m.r != ar || m.c != bc {
+		return &ShapeMismatchError{Op: "Gemm()", Want: []int{ar, bc}, Got: []int{m.r, m.c}}
+	}
+	if m == a || m == b {
+		return &ArgumentError{Op: "Gemm()", Msg: "the destination must not be an operand"}
+	}
+	switch beta {
+	case 0:
+		m.SetAll(0)
+	case 1:
+	default:
+		m.Mul(beta)
+	}
+	if alpha != 0 {
+		gemm(tA, tB, alpha, a, b, m)
+	}
+	return nil
+}
+
+// gemm adds alpha * op(a) * op(b) to o, whose shape must be that of the
+// product. The rows of o are split into blocks, which are handed to a pool
+// of worker goroutines if the product is large enough.
+func gemm[T Float](tA, tB Transpose, alpha T, a, b, o *Mat[T]) {
+	k := a.c
+	if tA {
+		k = a.r
+	}
+	blocks := (o.r + dotBlockI - 1) / dotBlockI
+	workers := runtime.GOMAXPROCS(0)
+	if workers > blocks {
+		workers = blocks
+	}
+	t := atomic.LoadInt64(&dotThreshold)
+	if t < 0 || int64(o.r)*int64(k)*int64(o.c) < t || workers < 2 {
+		for i := 0; i < o.r; i += dotBlockI {
+			gemmBlock(tA, tB, alpha, a, b, o, i)
+		}
+		return
+	}
+	rows := make(chan int, blocks)
+	for i := 0; i < o.r; i += dotBlockI {
+		rows <- i
+	}
+	close(rows)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				gemmBlock(tA, tB, alpha, a, b, o, i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// gemmBlock adds alpha * op(a) * op(b) to the rows of o starting at i0. If
+// b is used as it is, the loops are tiled and use the i-k-j order, so that
+// the innermost loop runs along contiguous rows of b and o. If b is
+// transposed, the rows of b are the columns of op(b), and each value of o is
+// a dot product along a row of b instead.
+func gemmBlock[T Float](tA, tB Transpose, alpha T, a, b, o *Mat[T], i0 int) {
+	i1 := minInt(i0+dotBlockI, o.r)
+	at := func(i, k int) T {
+		if tA {
+			return a.vals[k*a.stride+i]
+		}
+		return a.vals[i*a.stride+k]
+	}
+	if tB {
+		for i := i0; i < i1; i++ {
+			for j := 0; j < o.c; j++ {
+				row := b.row(j)
+				var s T
+				for k, x := range row {
+					s += at(i, k) * x
+				}
+				o.vals[i*o.stride+j] += alpha * s
+			}
+		}
+		return
+	}
+	for j0 := 0; j0 < b.c; j0 += dotBlockJ {
+		j1 := minInt(j0+dotBlockJ, b.c)
+		for k0 := 0; k0 < b.r; k0 += dotBlockK {
+			k1 := minInt(k0+dotBlockK, b.r)
+			for i := i0; i < i1; i++ {
+				dst := o.vals[i*o.stride+j0 : i*o.stride+j1]
+				for k := k0; k < k1; k++ {
+					vecAxpy(dst, alpha*at(i, k), b.vals[k*b.stride+j0:k*b.stride+j1])
+				}
+			}
+		}
+	}
+}
+
+// vecAxpy adds s * x to y in place. It uses the vecf64 and vecf32 packages
+// when T is exactly float64 or float32.
+func vecAxpy[T Float](y []T, s T, x []T) {
+	switch v := interface{}(y).(type) {
+	case []float64:
+		vecf64.IncrScale(interface{}(x).([]float64), float64(s), v)
+	case []float32:
+		vecf32.IncrScale(interface{}(x).([]float32), float32(s), v)
+	default:
+		for i := range y {
+			y[i] += s * x[i]
+		}
+	}
+}
@@ -0,0 +1,679 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+/*
+EigenSym is the eigendecomposition of a real symmetric matrix A, such that
+A = V * diag(Values) * transpose(V), where the eigenvalues are real and
+sorted in increasing order, and the columns of V are the corresponding
+orthonormal eigenvectors. It is computed with the cyclic Jacobi method, and
+is created with the EigenSym method of Matf64 or Matf32.
+*/
+type EigenSym[T Float] struct {
+	vals []T
+	vecs *Mat[T]
+}
+
+/*
+EigenSym computes the eigenvalues and eigenvectors of a symmetric matrix. The
+receiver is not modified.
+*/
+func (m *Mat[T]) EigenSym() *EigenSym[T] {
+	e, err := m.EigenSymE()
+	if err != nil {
+		handleErr(err)
+	}
+	return e
+}
+
+/*
+EigenSymE is the same as EigenSym, except that it returns an error instead
+of exiting the program. An *ArgumentError is returned if the matrix is not
+symmetric.
+*/
+func (m *Mat[T]) EigenSymE() (*EigenSym[T], error) {
+	if m.r != m.c {
+		return nil, &ShapeMismatchError{
+			Op:   "EigenSym()",
+			Want: []int{m.r, m.r},
+			Got:  []int{m.r, m.c},
+		}
+	}
+	if !m.isSymmetric() {
+		return nil, &ArgumentError{Op: "EigenSym()", Msg: "the matrix is not symmetric"}
+	}
+	n := m.r
+	a := m.Copy()
+	v := I[T](n)
+	eps := epsilon[T]()
+	for sweep := 0; sweep < 100; sweep++ {
+		var off, diag float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				x := float64(a.vals[i*n+j])
+				if i == j {
+					diag += x * x
+				} else {
+					off += x * x
+				}
+			}
+		}
+		if off <= eps*eps*diag {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := float64(a.vals[p*n+q])
+				if apq == 0 {
+					continue
+				}
+				theta := float64(a.vals[q*n+q]-a.vals[p*n+p]) / (2 * apq)
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				cs := 1 / math.Sqrt(t*t+1)
+				sn := t * cs
+				rotateCols(a, p, q, T(cs), T(sn))
+				rotateRows(a, p, q, T(cs), T(sn))
+				a.vals[p*n+q], a.vals[q*n+p] = 0, 0
+				rotateCols(v, p, q, T(cs), T(sn))
+			}
+		}
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return a.vals[order[i]*n+order[i]] < a.vals[order[j]*n+order[j]]
+	})
+	e := &EigenSym[T]{vals: make([]T, n), vecs: New[T](n, n)}
+	for jj, j := range order {
+		e.vals[jj] = a.vals[j*n+j]
+		for i := 0; i < n; i++ {
+			e.vecs.vals[i*n+jj] = v.vals[i*n+j]
+		}
+	}
+	return e, nil
+}
+
+// rotateRows applies a plane rotation to the rows j and k of m.
+func rotateRows[T Float](m *Mat[T], j, k int, cs, sn T) {
+	for i := 0; i < m.c; i++ {
+		x, y := m.vals[j*m.c+i], m.vals[k*m.c+i]
+		m.vals[j*m.c+i] = cs*x - sn*y
+		m.vals[k*m.c+i] = sn*x + cs*y
+	}
+}
+
+/*
+Values returns a copy of the eigenvalues, in increasing order.
+*/
+func (e *EigenSym[T]) Values() []T {
+	v := make([]T, len(e.vals))
+	copy(v, e.vals)
+	return v
+}
+
+/*
+Vectors returns a copy of the eigenvectors, stored as the columns of a
+matrix. Column i is the eigenvector of Values()[i].
+*/
+func (e *EigenSym[T]) Vectors() *Mat[T] {
+	return e.vecs.Copy()
+}
+
+/*
+Eigen is the eigendecomposition of a general real square matrix A, such that
+A * V = V * diag(Values), where the eigenvalues and eigenvectors are complex
+in general. The eigenvalues of a real matrix come in complex conjugate
+pairs, which are stored next to each other, with the one that has a
+positive imaginary part first. It is computed by reducing A to Hessenberg
+form followed by the shifted QR algorithm, and is created with the Eigen
+method of Matf64 or Matf32.
+*/
+type Eigen struct {
+	vals []complex128
+	vecs *Matc128
+}
+
+/*
+Values returns a copy of the eigenvalues.
+*/
+func (e *Eigen) Values() []complex128 {
+	v := make([]complex128, len(e.vals))
+	copy(v, e.vals)
+	return v
+}
+
+/*
+Vectors returns a copy of the eigenvectors, stored as the columns of a
+Matc128. Column i is the eigenvector of Values()[i], normalized to have a
+2-norm of 1.
+*/
+func (e *Eigen) Vectors() *Matc128 {
+	return e.vecs.Copy()
+}
+
+/*
+Eigen computes the eigenvalues and eigenvectors of a general square matrix.
+The computation is carried out in float64 for both Matf64 and Matf32. The
+receiver is not modified. For symmetric matrices, EigenSym is faster and
+returns real results.
+*/
+func (m *Mat[T]) Eigen() *Eigen {
+	e, err := m.EigenE()
+	if err != nil {
+		handleErr(err)
+	}
+	return e
+}
+
+/*
+EigenE is the same as Eigen, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) EigenE() (*Eigen, error) {
+	if m.r != m.c {
+		return nil, &ShapeMismatchError{
+			Op:   "Eigen()",
+			Want: []int{m.r, m.r},
+			Got:  []int{m.r, m.c},
+		}
+	}
+	n := m.r
+	h := make([][]float64, n)
+	for i := range h {
+		h[i] = make([]float64, n)
+		for j := range h[i] {
+			x := float64(m.vals[i*m.stride+j])
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				s := "the matrix contains NaN or Inf values"
+				return nil, &ArgumentError{Op: "Eigen()", Msg: s}
+			}
+			h[i][j] = x
+		}
+	}
+	v := hessenberg(h)
+	d, e, ok := hqr(h, v)
+	if !ok {
+		s := "the QR iteration did not converge"
+		return nil, &ArgumentError{Op: "Eigen()", Msg: s}
+	}
+	out := &Eigen{vals: make([]complex128, n), vecs: Newc128(n, n)}
+	for j := 0; j < n; j++ {
+		out.vals[j] = complex(d[j], e[j])
+		switch {
+		case e[j] == 0:
+			for i := 0; i < n; i++ {
+				out.vecs.vals[i*n+j] = complex(v[i][j], 0)
+			}
+		case e[j] > 0:
+			for i := 0; i < n; i++ {
+				out.vecs.vals[i*n+j] = complex(v[i][j], v[i][j+1])
+				out.vecs.vals[i*n+j+1] = complex(v[i][j], -v[i][j+1])
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		var norm float64
+		for i := 0; i < n; i++ {
+			norm = math.Hypot(norm, cmplx.Abs(out.vecs.vals[i*n+j]))
+		}
+		if norm == 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			out.vecs.vals[i*n+j] /= complex(norm, 0)
+		}
+	}
+	return out, nil
+}
+
+// hessenberg reduces h to upper Hessenberg form in place with Householder
+// similarity transformations, and returns the accumulated transformations.
+func hessenberg(h [][]float64) [][]float64 {
+	n := len(h)
+	high := n - 1
+	ort := make([]float64, n)
+	for m := 1; m <= high-1; m++ {
+		var scale float64
+		for i := m; i <= high; i++ {
+			scale += math.Abs(h[i][m-1])
+		}
+		if scale == 0 {
+			continue
+		}
+		var s float64
+		for i := high; i >= m; i-- {
+			ort[i] = h[i][m-1] / scale
+			s += ort[i] * ort[i]
+		}
+		g := math.Sqrt(s)
+		if ort[m] > 0 {
+			g = -g
+		}
+		s -= ort[m] * g
+		ort[m] -= g
+		for j := m; j < n; j++ {
+			var f float64
+			for i := high; i >= m; i-- {
+				f += ort[i] * h[i][j]
+			}
+			f /= s
+			for i := m; i <= high; i++ {
+				h[i][j] -= f * ort[i]
+			}
+		}
+		for i := 0; i <= high; i++ {
+			var f float64
+			for j := high; j >= m; j-- {
+				f += ort[j] * h[i][j]
+			}
+			f /= s
+			for j := m; j <= high; j++ {
+				h[i][j] -= f * ort[j]
+			}
+		}
+		ort[m] *= scale
+		h[m][m-1] = scale * g
+	}
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+	for m := high - 1; m >= 1; m-- {
+		if h[m][m-1] == 0 {
+			continue
+		}
+		for i := m + 1; i <= high; i++ {
+			ort[i] = h[i][m-1]
+		}
+		for j := m; j <= high; j++ {
+			var g float64
+			for i := m; i <= high; i++ {
+				g += ort[i] * v[i][j]
+			}
+			g = (g / ort[m]) / h[m][m-1]
+			for i := m; i <= high; i++ {
+				v[i][j] += g * ort[i]
+			}
+		}
+	}
+	return v
+}
+
+// cdiv returns the real and imaginary parts of (xr + xi*i) / (yr + yi*i).
+func cdiv(xr, xi, yr, yi float64) (float64, float64) {
+	q := complex(xr, xi) / complex(yr, yi)
+	return real(q), imag(q)
+}
+
+// hqr reduces the Hessenberg matrix h to real Schur form with the shifted
+// QR algorithm, and returns the real and imaginary parts of the eigenvalues.
+// On return, the columns of v hold the real eigenvectors, or the real and
+// imaginary parts of the complex ones, which are found by back substitution
+// in the Schur form and transformation with the v passed in. This follows
+// the hqr2 routine of EISPACK, and like it, gives up and returns false after
+// 30 iterations per eigenvalue.
+func hqr(h, v [][]float64) ([]float64, []float64, bool) {
+	nn := len(h)
+	d, e := make([]float64, nn), make([]float64, nn)
+	n := nn - 1
+	low, high := 0, nn-1
+	eps := math.Pow(2, -52)
+	var exshift, p, q, r, s, z, t, w, x, y float64
+
+	var norm float64
+	for i := 0; i < nn; i++ {
+		for j := maxInt(i-1, 0); j < nn; j++ {
+			norm += math.Abs(h[i][j])
+		}
+	}
+
+	iter, itn := 0, 30*nn
+	for n >= low {
+		l := n
+		for l > low {
+			s = math.Abs(h[l-1][l-1]) + math.Abs(h[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(h[l][l-1]) < eps*s {
+				break
+			}
+			l--
+		}
+
+		switch {
+		case l == n:
+			// One root found.
+			h[n][n] += exshift
+			d[n], e[n] = h[n][n], 0
+			n--
+			iter = 0
+		case l == n-1:
+			// Two roots found.
+			w = h[n][n-1] * h[n-1][n]
+			p = (h[n-1][n-1] - h[n][n]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			h[n][n] += exshift
+			h[n-1][n-1] += exshift
+			x = h[n][n]
+			if q >= 0 {
+				// A real pair.
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				d[n-1] = x + z
+				d[n] = d[n-1]
+				if z != 0 {
+					d[n] = x - w/z
+				}
+				e[n-1], e[n] = 0, 0
+				x = h[n][n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p, q = x/s, z/s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+				for j := n - 1; j < nn; j++ {
+					z = h[n-1][j]
+					h[n-1][j] = q*z + p*h[n][j]
+					h[n][j] = q*h[n][j] - p*z
+				}
+				for i := 0; i <= n; i++ {
+					z = h[i][n-1]
+					h[i][n-1] = q*z + p*h[i][n]
+					h[i][n] = q*h[i][n] - p*z
+				}
+				for i := low; i <= high; i++ {
+					z = v[i][n-1]
+					v[i][n-1] = q*z + p*v[i][n]
+					v[i][n] = q*v[i][n] - p*z
+				}
+			} else {
+				// A complex pair.
+				d[n-1], d[n] = x+p, x+p
+				e[n-1], e[n] = z, -z
+			}
+			n -= 2
+			iter = 0
+		default:
+			// No convergence yet.
+			if itn == 0 {
+				return nil, nil, false
+			}
+			itn--
+			x = h[n][n]
+			y, w = 0, 0
+			if l < n {
+				y = h[n-1][n-1]
+				w = h[n][n-1] * h[n-1][n]
+			}
+			// Wilkinson's original ad hoc shift.
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= n; i++ {
+					h[i][i] -= x
+				}
+				s = math.Abs(h[n][n-1]) + math.Abs(h[n-1][n-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+			// MATLAB's new ad hoc shift.
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := low; i <= n; i++ {
+						h[i][i] -= s
+					}
+					exshift += s
+					x, y, w = 0.964, 0.964, 0.964
+				}
+			}
+			iter++
+
+			// Look for two consecutive small sub-diagonal elements.
+			m := n - 2
+			for m >= l {
+				z = h[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/h[m+1][m] + h[m][m+1]
+				q = h[m+1][m+1] - z - r - s
+				r = h[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				lhs := math.Abs(h[m][m-1]) * (math.Abs(q) + math.Abs(r))
+				rhs := eps * (math.Abs(p) * (math.Abs(h[m-1][m-1]) + math.Abs(z) +
+					math.Abs(h[m+1][m+1])))
+				if lhs < rhs {
+					break
+				}
+				m--
+			}
+			for i := m + 2; i <= n; i++ {
+				h[i][i-2] = 0
+				if i > m+2 {
+					h[i][i-3] = 0
+				}
+			}
+
+			// Double QR step involving rows l:n and columns m:n.
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+				if k != m {
+					p = h[k][k-1]
+					q = h[k+1][k-1]
+					r = 0
+					if notlast {
+						r = h[k+2][k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s == 0 {
+					continue
+				}
+				if k != m {
+					h[k][k-1] = -s * x
+				} else if l != m {
+					h[k][k-1] = -h[k][k-1]
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+				for j := k; j < nn; j++ {
+					p = h[k][j] + q*h[k+1][j]
+					if notlast {
+						p += r * h[k+2][j]
+						h[k+2][j] -= p * z
+					}
+					h[k][j] -= p * x
+					h[k+1][j] -= p * y
+				}
+				for i := 0; i <= minInt(n, k+3); i++ {
+					p = x*h[i][k] + y*h[i][k+1]
+					if notlast {
+						p += z * h[i][k+2]
+						h[i][k+2] -= p * r
+					}
+					h[i][k] -= p
+					h[i][k+1] -= p * q
+				}
+				for i := low; i <= high; i++ {
+					p = x*v[i][k] + y*v[i][k+1]
+					if notlast {
+						p += z * v[i][k+2]
+						v[i][k+2] -= p * r
+					}
+					v[i][k] -= p
+					v[i][k+1] -= p * q
+				}
+			}
+		}
+	}
+
+	// Back substitute to find the vectors of the upper triangular form.
+	if norm == 0 {
+		return d, e, true
+	}
+	for n = nn - 1; n >= 0; n-- {
+		p, q = d[n], e[n]
+		switch {
+		case q == 0:
+			// A real vector.
+			l := n
+			h[n][n] = 1
+			for i := n - 1; i >= 0; i-- {
+				w = h[i][i] - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += h[i][j] * h[j][n]
+				}
+				if e[i] < 0 {
+					z = w
+					s = r
+					continue
+				}
+				l = i
+				if e[i] == 0 {
+					if w != 0 {
+						h[i][n] = -r / w
+					} else {
+						h[i][n] = -r / (eps * norm)
+					}
+				} else {
+					x = h[i][i+1]
+					y = h[i+1][i]
+					q = (d[i]-p)*(d[i]-p) + e[i]*e[i]
+					t = (x*s - z*r) / q
+					h[i][n] = t
+					if math.Abs(x) > math.Abs(z) {
+						h[i+1][n] = (-r - w*t) / x
+					} else {
+						h[i+1][n] = (-s - y*t) / z
+					}
+				}
+				// Overflow control.
+				t = math.Abs(h[i][n])
+				if (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n] /= t
+					}
+				}
+			}
+		case q < 0:
+			// A complex vector, whose last component is imaginary.
+			l := n - 1
+			if math.Abs(h[n][n-1]) > math.Abs(h[n-1][n]) {
+				h[n-1][n-1] = q / h[n][n-1]
+				h[n-1][n] = -(h[n][n] - p) / h[n][n-1]
+			} else {
+				h[n-1][n-1], h[n-1][n] = cdiv(0, -h[n-1][n], h[n-1][n-1]-p, q)
+			}
+			h[n][n-1] = 0
+			h[n][n] = 1
+			for i := n - 2; i >= 0; i-- {
+				var ra, sa float64
+				for j := l; j <= n; j++ {
+					ra += h[i][j] * h[j][n-1]
+					sa += h[i][j] * h[j][n]
+				}
+				w = h[i][i] - p
+				if e[i] < 0 {
+					z = w
+					r = ra
+					s = sa
+					continue
+				}
+				l = i
+				if e[i] == 0 {
+					h[i][n-1], h[i][n] = cdiv(-ra, -sa, w, q)
+				} else {
+					// Solve the complex equations.
+					x = h[i][i+1]
+					y = h[i+1][i]
+					vr := (d[i]-p)*(d[i]-p) + e[i]*e[i] - q*q
+					vi := (d[i] - p) * 2 * q
+					if vr == 0 && vi == 0 {
+						vr = eps * norm * (math.Abs(w) + math.Abs(q) + math.Abs(x) +
+							math.Abs(y) + math.Abs(z))
+					}
+					h[i][n-1], h[i][n] = cdiv(x*r-z*ra+q*sa, x*s-z*sa-q*ra, vr, vi)
+					if math.Abs(x) > (math.Abs(z) + math.Abs(q)) {
+						h[i+1][n-1] = (-ra - w*h[i][n-1] + q*h[i][n]) / x
+						h[i+1][n] = (-sa - w*h[i][n] - q*h[i][n-1]) / x
+					} else {
+						h[i+1][n-1], h[i+1][n] = cdiv(-r-y*h[i][n-1], -s-y*h[i][n], z, q)
+					}
+				}
+				// Overflow control.
+				t = math.Max(math.Abs(h[i][n-1]), math.Abs(h[i][n]))
+				if (eps*t)*t > 1 {
+					for j := i; j <= n; j++ {
+						h[j][n-1] /= t
+						h[j][n] /= t
+					}
+				}
+			}
+		}
+	}
+
+	// Back transform to get the eigenvectors of the original matrix.
+	for j := nn - 1; j >= low; j-- {
+		for i := low; i <= high; i++ {
+			z = 0
+			for k := low; k <= minInt(j, high); k++ {
+				z += v[i][k] * h[k][j]
+			}
+			v[i][j] = z
+		}
+	}
+	return d, e, true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
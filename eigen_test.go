@@ -0,0 +1,111 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEigenSymf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, -1, 0},
+		{-1, 2, -1},
+		{0, -1, 2},
+	})
+	e := m.EigenSym()
+	want := []float64{2 - math.Sqrt2, 2, 2 + math.Sqrt2}
+	assert.InDeltaSlice(t, want, e.Values(), 1e-12, "should be sorted")
+	v := e.Vectors()
+	assert.InDeltaSlice(t, I[float64](3).vals, v.T().Dot(v).vals, 1e-12,
+		"should be orthonormal")
+	d := Newf64(3)
+	for i, x := range e.Values() {
+		d.Set(i, i, x)
+	}
+	assert.InDeltaSlice(t, m.vals, v.Dot(d).Dot(v.T()).vals, 1e-12, "should reconstruct")
+}
+
+func TestEigenSymErrorsf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	_, err := Matf64FromData([][]float64{{1, 2}, {3, 4}}).EigenSymE()
+	var a *ArgumentError
+	assert.True(t, errors.As(err, &a), "should not be symmetric")
+	assert.Panics(t, func() { Newf64(2, 3).EigenSym() }, "not square")
+}
+
+func TestEigenf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	// A rotation by 90 degrees has the eigenvalues i and -i.
+	rot := Matf64FromData([][]float64{
+		{0, -1},
+		{1, 0},
+	})
+	e := rot.Eigen()
+	assert.Equal(t, 2, len(e.Values()), "should be equal")
+	assert.InDelta(t, 0, cmplx.Abs(e.Values()[0]-1i), 1e-12, "should be i")
+	assert.InDelta(t, 0, cmplx.Abs(e.Values()[1]+1i), 1e-12, "should be -i")
+
+	m := Matf64FromData([][]float64{
+		{1, 2, 0, 3},
+		{-2, 1, 4, 0},
+		{0, 1, 3, -1},
+		{5, 0, 2, 1},
+	})
+	// A cyclic permutation stalls the QR iteration without the ad hoc shifts,
+	// which must still find its eigenvalues within the iteration limit.
+	perm := Matf64FromData([][]float64{
+		{0, 0, 0, 1},
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+	})
+	for _, a := range []*Matf64{rot, m, perm} {
+		e = a.Eigen()
+		n, _ := a.Shape()
+		ac := Matc128FromParts(a, Newf64(n, n))
+		av := ac.Dot(e.Vectors())
+		for j, lambda := range e.Values() {
+			for i := 0; i < n; i++ {
+				want := lambda * e.Vectors().Get(i, j)
+				assert.InDelta(t, 0, cmplx.Abs(av.Get(i, j)-want), 1e-10,
+					"A*v should equal lambda*v")
+			}
+		}
+		var trace complex128
+		for _, lambda := range e.Values() {
+			trace += lambda
+		}
+		assert.InDelta(t, traceOf(a), real(trace), 1e-10, "should be the trace")
+		assert.InDelta(t, 0, imag(trace), 1e-10, "should be real")
+	}
+	nan := Newf64(2)
+	nan.Set(0, 1, math.NaN())
+	_, err := nan.EigenE()
+	assert.NotNil(t, err, "should not accept NaN")
+	assert.Panics(t, func() { Newf64(2, 3).Eigen() }, "not square")
+}
+
+func traceOf(m *Matf64) float64 {
+	var t float64
+	for i := 0; i < m.r; i++ {
+		t += m.Get(i, i)
+	}
+	return t
+}
+
+func TestEigenSymf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{2, 1},
+		{1, 2},
+	})
+	assert.InDeltaSlice(t, []float32{1, 3}, m.EigenSym().Values(), 1e-5, "should be equal")
+	vals := m.Eigen().Values()
+	assert.InDelta(t, 4, real(vals[0]+vals[1]), 1e-5, "should be the trace")
+}
@@ -0,0 +1,204 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Abs sets each element of a Mat to its absolute value, and returns the Mat.
+Like Map and the other element-wise methods below, Abs modifies the receiver
+so that calls can be chained. To keep the original values, call it on a
+copy:
+
+	n := m.Copy().Abs()
+*/
+func (m *Mat[T]) Abs() *Mat[T] {
+	return m.apply(math.Abs)
+}
+
+/*
+Sqrt sets each element of a Mat to its square root. Negative values become
+NaN.
+*/
+func (m *Mat[T]) Sqrt() *Mat[T] {
+	return m.apply(math.Sqrt)
+}
+
+/*
+Exp sets each element of a Mat to e raised to the power of that element.
+*/
+func (m *Mat[T]) Exp() *Mat[T] {
+	return m.apply(math.Exp)
+}
+
+/*
+Log sets each element of a Mat to its natural logarithm. Zeros become -Inf,
+and negative values become NaN.
+*/
+func (m *Mat[T]) Log() *Mat[T] {
+	return m.apply(math.Log)
+}
+
+/*
+Log1p sets each element x of a Mat to the natural logarithm of 1+x, which is
+more accurate than Log when x is near zero.
+*/
+func (m *Mat[T]) Log1p() *Mat[T] {
+	return m.apply(math.Log1p)
+}
+
+/*
+Pow raises each element of a Mat to the passed power, following the special
+cases of math.Pow.
+*/
+func (m *Mat[T]) Pow(p float64) *Mat[T] {
+	return m.apply(func(x float64) float64 {
+		return math.Pow(x, p)
+	})
+}
+
+/*
+Sin sets each element of a Mat to its sine, where the elements are in
+radians.
+*/
+func (m *Mat[T]) Sin() *Mat[T] {
+	return m.apply(math.Sin)
+}
+
+/*
+Cos sets each element of a Mat to its cosine, where the elements are in
+radians.
+*/
+func (m *Mat[T]) Cos() *Mat[T] {
+	return m.apply(math.Cos)
+}
+
+/*
+Tanh sets each element of a Mat to its hyperbolic tangent.
+*/
+func (m *Mat[T]) Tanh() *Mat[T] {
+	return m.apply(math.Tanh)
+}
+
+/*
+Sigmoid sets each element x of a Mat to the logistic function 1/(1+e^-x).
+Large negative values give 0, rather than overflowing.
+*/
+func (m *Mat[T]) Sigmoid() *Mat[T] {
+	return m.apply(func(x float64) float64 {
+		if x < 0 {
+			e := math.Exp(x)
+			return e / (1 + e)
+		}
+		return 1 / (1 + math.Exp(-x))
+	})
+}
+
+/*
+Round sets each element of a Mat to the nearest integer, rounding half away
+from zero.
+*/
+func (m *Mat[T]) Round() *Mat[T] {
+	return m.apply(math.Round)
+}
+
+/*
+Sign sets each element of a Mat to -1, 0 or 1, depending on whether it is
+negative, zero or positive. NaN values are left unchanged.
+*/
+func (m *Mat[T]) Sign() *Mat[T] {
+	return m.apply(func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return x
+	})
+}
+
+/*
+Clip limits the elements of a Mat to the closed interval [lo, hi]. Elements
+below lo are set to lo, and those above hi are set to hi. NaN values are left
+unchanged. lo cannot be greater than hi.
+*/
+func (m *Mat[T]) Clip(lo, hi float64) *Mat[T] {
+	if err := m.ClipE(lo, hi); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ClipE is the same as Clip, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) ClipE(lo, hi float64) error {
+	if lo > hi {
+		s := "the lower bound %v is greater than the upper bound %v"
+		return &ArgumentError{Op: "Clip()", Msg: fmt.Sprintf(s, lo, hi)}
+	}
+	l, h := T(lo), T(hi)
+	m.each(func(_ int, run []T) {
+		for i, v := range run {
+			if v < l {
+				run[i] = l
+			} else if v > h {
+				run[i] = h
+			}
+		}
+	})
+	return nil
+}
+
+// apply sets each element of m to f of that element, computed in float64.
+func (m *Mat[T]) apply(f func(float64) float64) *Mat[T] {
+	m.each(func(_ int, run []T) {
+		for i, v := range run {
+			run[i] = T(f(float64(v)))
+		}
+	})
+	return m
+}
+
+/*
+Positive reports whether the passed element is greater than zero. It is
+meant to be used with All and Any:
+
+	m.All(matrix.Positive[float64]) // True if all elements of m are positive.
+*/
+func Positive[T Float](v *T) bool {
+	return *v > 0
+}
+
+/*
+Negative reports whether the passed element is less than zero.
+*/
+func Negative[T Float](v *T) bool {
+	return *v < 0
+}
+
+/*
+Zero reports whether the passed element is equal to zero.
+*/
+func Zero[T Float](v *T) bool {
+	return *v == 0
+}
+
+/*
+NaN reports whether the passed element is a NaN:
+
+	m.Any(matrix.NaN[float32]) // True if any element of m is a NaN.
+*/
+func NaN[T Float](v *T) bool {
+	return *v != *v
+}
+
+/*
+Inf reports whether the passed element is positive or negative infinity.
+*/
+func Inf[T Float](v *T) bool {
+	return math.IsInf(float64(*v), 0)
+}
@@ -0,0 +1,55 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementwisef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-4, -0.5, 0, 2.5, 9})
+	assert.Equal(t, []float64{4, 0.5, 0, 2.5, 9}, m.Copy().Abs().vals, "Abs")
+	assert.Equal(t, []float64{-1, -1, 0, 1, 1}, m.Copy().Sign().vals, "Sign")
+	assert.Equal(t, []float64{-4, -1, 0, 3, 9}, m.Copy().Round().vals, "Round")
+	assert.Equal(t, []float64{-1, -0.5, 0, 2.5, 3}, m.Copy().Clip(-1, 3).vals, "Clip")
+	assert.Equal(t, []float64{16, 0.25, 0, 6.25, 81}, m.Copy().Pow(2).vals, "Pow")
+	assert.Equal(t, []float64{2, 3}, Matf64FromData([]float64{4, 9}).Sqrt().vals, "Sqrt")
+	assert.True(t, math.IsNaN(m.Copy().Sqrt().vals[0]), "Sqrt of a negative")
+	assert.Equal(t, []float64{-4, -0.5, 0, 2.5, 9}, m.vals, "m should not change")
+
+	n := Matf64FromData([]float64{0, 1})
+	assert.Equal(t, []float64{1, math.E}, n.Copy().Exp().vals, "Exp")
+	assert.Equal(t, 0.0, n.Copy().Exp().Log().vals[0], "Log")
+	assert.Equal(t, math.Log1p(1e-10), Matf64FromData([]float64{1e-10}).Log1p().vals[0],
+		"Log1p")
+	assert.Equal(t, []float64{0, math.Sin(1)}, n.Copy().Sin().vals, "Sin")
+	assert.Equal(t, []float64{1, math.Cos(1)}, n.Copy().Cos().vals, "Cos")
+	assert.Equal(t, []float64{0, math.Tanh(1)}, n.Copy().Tanh().vals, "Tanh")
+
+	s := Matf64FromData([]float64{-1000, 0, 1000}).Sigmoid()
+	assert.Equal(t, []float64{0, 0.5, 1}, s.vals, "Sigmoid")
+
+	v := rangeMatf64(3, 3).View(1, 3, 1, 3).Clip(5, 7)
+	assert.Equal(t, []float64{5, 5, 7, 7}, v.ToSlice1D(), "Clip on a view")
+
+	assert.NotNil(t, m.ClipE(1, 0), "lo is greater than hi")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.Clip(1, 0) }, "lo is greater than hi")
+}
+
+func TestPredicatesf32(t *testing.T) {
+	t.Helper()
+	nan, inf := float32(math.NaN()), float32(math.Inf(-1))
+	m := Matf32FromData([]float32{1, 2, 3})
+	assert.True(t, m.All(Positive[float32]), "all are positive")
+	assert.False(t, m.Any(Negative[float32]), "none are negative")
+	assert.False(t, m.Any(Zero[float32]), "none are zero")
+	n := Matf32FromData([]float32{0, -1, nan, inf})
+	assert.True(t, n.Any(Zero[float32]), "one is zero")
+	assert.True(t, n.Any(NaN[float32]), "one is NaN")
+	assert.True(t, n.Any(Inf[float32]), "one is Inf")
+	assert.False(t, n.All(Negative[float32]), "not all are negative")
+	assert.False(t, m.Any(NaN[float32]), "none are NaN")
+}
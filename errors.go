@@ -3,22 +3,254 @@ package matrix
 import (
 	"fmt"
 	"os"
-	"runtime/debug"
+	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 )
 
-func printErr(s string) {
-	fmt.Println(s)
-	q := string(debug.Stack())
-	w := strings.Split(q, "\n")
-	fmt.Println(strings.Join(w[7:], "\n"))
-	os.Exit(1)
+/*
+ShapeMismatchError is returned when the shape of a Matf64, Matf32 or a slice
+passed to a function or method does not match the shape that was expected.
+Want holds the expected shape, and Got holds the shape that was received.
+For example:
+
+	_, err := m.DotE(n)
+	var e *matrix.ShapeMismatchError
+	if errors.As(err, &e) {
+		fmt.Println(e.Want, e.Got)
+	}
+*/
+type ShapeMismatchError struct {
+	Op   string
+	Want []int
+	Got  []int
+}
+
+func (e *ShapeMismatchError) Error() string {
+	s := "In %s, expected shape %v, but received shape %v."
+	return fmt.Sprintf(s, e.Op, e.Want, e.Got)
+}
+
+/*
+IndexOutOfRangeError is returned when a row or column index is outside of
+the bounds of a Matf64 or Matf32. Axis is 0 for rows and 1 for columns, and
+Bound is the number of rows or columns along that axis.
+*/
+type IndexOutOfRangeError struct {
+	Op    string
+	Axis  int
+	Index int
+	Bound int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	axis := "row"
+	if e.Axis == 1 {
+		axis = "column"
+	}
+	s := "In %s, the %s index %d is out of range for a length of %d."
+	return fmt.Sprintf(s, e.Op, axis, e.Index, e.Bound)
+}
+
+/*
+UnsupportedTypeError is returned when a function or method which accepts an
+interface{} receives a value of a type that it can not handle.
+*/
+type UnsupportedTypeError struct {
+	Op   string
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	s := "In %s, values of type \"%v\" are not supported."
+	return fmt.Sprintf(s, e.Op, e.Type)
+}
+
+/*
+ArgumentError is returned when a function or method receives the wrong
+number of arguments, or an argument with an invalid value.
+*/
+type ArgumentError struct {
+	Op  string
+	Msg string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("In %s, %s.", e.Op, e.Msg)
+}
+
+/*
+ParseError is returned when an item in a file can not be converted to a
+number. Line is the line number, starting at 1, and Item is the zero based
+index of the value within that line.
+*/
+type ParseError struct {
+	Op    string
+	Line  int
+	Item  int
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	s := "In %s, item %d in line %d is \"%s\", which cannot be parsed: %v"
+	return fmt.Sprintf(s, e.Op, e.Item, e.Line, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+/*
+OverflowError is returned by the checked arithmetic methods of the integer
+matrices, such as (*Mati64).CheckedSum, when the result does not fit in the
+element type.
+*/
+type OverflowError struct {
+	Op   string
+	Type reflect.Type
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("In %s, the result overflows the range of %v.", e.Op, e.Type)
 }
 
-func printHelperErr(s string) {
-	fmt.Println(s)
-	q := string(debug.Stack())
-	w := strings.Split(q, "\n")
-	fmt.Println(strings.Join(w[9:], "\n"))
+/*
+SingularMatrixError is returned when a matrix is singular, or so close to
+singular that the result of an operation such as Solve or Inverse can not be
+trusted. Cond holds the estimated condition number of the matrix, which is
++Inf for an exactly singular matrix.
+*/
+type SingularMatrixError struct {
+	Op   string
+	Cond float64
+}
+
+func (e *SingularMatrixError) Error() string {
+	s := "In %s, the matrix is singular or nearly singular (condition number %g)."
+	return fmt.Sprintf(s, e.Op, e.Cond)
+}
+
+/*
+NotPositiveDefiniteError is returned by Cholesky when the matrix is not
+symmetric positive definite. Col is the first column at which the
+factorization failed.
+*/
+type NotPositiveDefiniteError struct {
+	Op  string
+	Col int
+}
+
+func (e *NotPositiveDefiniteError) Error() string {
+	s := "In %s, the matrix is not positive definite (failed at column %d)."
+	return fmt.Sprintf(s, e.Op, e.Col)
+}
+
+/*
+ErrorHandler is a function which is called with the error encountered by the
+functions and methods of this package which do not return an error, such as
+Newf64 or (*Matf64).Dot. The error returning counterparts of these, such as
+Newf64E or (*Matf64).DotE, never call the ErrorHandler.
+*/
+type ErrorHandler func(error)
+
+var (
+	errHandlerMu sync.RWMutex
+	errHandler   ErrorHandler = ExitOnError
+)
+
+/*
+SetErrorHandler sets the ErrorHandler which is called when an error is
+encountered, and returns the previous ErrorHandler. Passing nil restores the
+default handler, ExitOnError. It is safe to call SetErrorHandler from
+multiple goroutines. For example, to panic instead of exiting within a test:
+
+	defer matrix.SetErrorHandler(matrix.SetErrorHandler(matrix.PanicOnError))
+*/
+func SetErrorHandler(h ErrorHandler) ErrorHandler {
+	if h == nil {
+		h = ExitOnError
+	}
+	errHandlerMu.Lock()
+	defer errHandlerMu.Unlock()
+	old := errHandler
+	errHandler = h
+	return old
+}
+
+/*
+ExitOnError is the default ErrorHandler. It prints the error and the stack
+trace of the calling code, starting from the first caller outside of this
+package, and exits with signal 1.
+*/
+func ExitOnError(err error) {
+	fmt.Println(err)
+	fmt.Print(callerStack())
 	os.Exit(1)
 }
+
+/*
+PanicOnError is an ErrorHandler which panics with the encountered error. The
+recovered value can be inspected with errors.As, for example:
+
+	defer func() {
+		if r := recover(); r != nil {
+			var e *matrix.ShapeMismatchError
+			if err, ok := r.(error); ok && errors.As(err, &e) {
+				// handle the mismatch
+			}
+		}
+	}()
+*/
+func PanicOnError(err error) {
+	panic(err)
+}
+
+/*
+Logger is the interface that LogOnError writes to. A *log.Logger from the
+standard library satisfies it.
+*/
+type Logger interface {
+	Println(v ...interface{})
+}
+
+/*
+LogOnError returns an ErrorHandler which writes the encountered error to the
+passed Logger, and lets the program continue. In this case the function or
+method which encountered the error returns early: constructors such as
+Newf64 return nil, methods which return the receiver return it unchanged,
+and methods which return values return zero values.
+*/
+func LogOnError(l Logger) ErrorHandler {
+	return func(err error) {
+		l.Println(err)
+	}
+}
+
+func handleErr(err error) {
+	errHandlerMu.RLock()
+	h := errHandler
+	errHandlerMu.RUnlock()
+	h(err)
+}
+
+// callerStack returns the stack trace of the current goroutine, omitting the
+// frames that belong to this package.
+func callerStack() string {
+	pkg := reflect.TypeOf(Matf64{}).PkgPath() + "."
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(1, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	var b strings.Builder
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkg) {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
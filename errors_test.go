@@ -0,0 +1,112 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShapeMismatchError(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	_, err := m.DotE(Newf64(3, 4))
+	var e *ShapeMismatchError
+	assert.True(t, errors.As(err, &e), "should be a ShapeMismatchError")
+	assert.Equal(t, "Dot()", e.Op, "should be equal")
+	assert.Equal(t, []int{4, 4}, e.Want, "should be equal")
+	assert.Equal(t, []int{3, 4}, e.Got, "should be equal")
+
+	err = Newf32(2, 3).AddE(Newf32(3, 2))
+	assert.True(t, errors.As(err, &e), "should be a ShapeMismatchError")
+	assert.Equal(t, []int{2, 3}, e.Want, "should be equal")
+	assert.Equal(t, []int{3, 2}, e.Got, "should be equal")
+}
+
+func TestIndexOutOfRangeError(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	_, err := m.ColE(-5)
+	var e *IndexOutOfRangeError
+	assert.True(t, errors.As(err, &e), "should be an IndexOutOfRangeError")
+	assert.Equal(t, 1, e.Axis, "should be equal")
+	assert.Equal(t, -5, e.Index, "should be equal")
+	assert.Equal(t, 4, e.Bound, "should be equal")
+
+	_, err = Newf32(3, 4).SumE(0, 3)
+	assert.True(t, errors.As(err, &e), "should be an IndexOutOfRangeError")
+	assert.Equal(t, 0, e.Axis, "should be equal")
+	assert.Equal(t, 3, e.Bound, "should be equal")
+}
+
+func TestUnsupportedTypeError(t *testing.T) {
+	t.Helper()
+	_, err := Matf64FromDataE([]int{1, 2})
+	var e *UnsupportedTypeError
+	assert.True(t, errors.As(err, &e), "should be an UnsupportedTypeError")
+	assert.Equal(t, reflect.TypeOf([]int{}), e.Type, "should be equal")
+
+	err = Newf32(2).MulE("a")
+	assert.True(t, errors.As(err, &e), "should be an UnsupportedTypeError")
+	assert.Equal(t, "Mul()", e.Op, "should be equal")
+}
+
+func TestArgumentError(t *testing.T) {
+	t.Helper()
+	_, _, err := Newf64(2).MinE(2, 0)
+	var e *ArgumentError
+	assert.True(t, errors.As(err, &e), "should be an ArgumentError")
+	assert.Equal(t, "Min()", e.Op, "should be equal")
+}
+
+func TestParseError(t *testing.T) {
+	t.Helper()
+	filename := "parse_error_test.csv"
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	f.Write([]byte("1.0,2.0\n3.0,x"))
+	f.Close()
+	_, err = Matf64FromCSVE(filename)
+	var e *ParseError
+	assert.True(t, errors.As(err, &e), "should be a ParseError")
+	assert.Equal(t, 2, e.Line, "should be equal")
+	assert.Equal(t, 1, e.Item, "should be equal")
+	assert.Equal(t, "x", e.Value, "should be equal")
+
+	_, err = Matf64FromCSVE("non-existent-file")
+	assert.True(t, errors.Is(err, os.ErrNotExist), "should wrap the os error")
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	t.Helper()
+	var buf bytes.Buffer
+	old := SetErrorHandler(LogOnError(log.New(&buf, "", 0)))
+	m := Newf64(2, 3)
+	assert.Equal(t, m, m.Add(Newf64(3, 2)), "should return the receiver")
+	assert.Contains(t, buf.String(), "Add()", "should log the error")
+	assert.Nil(t, Newf64(1, 2, 3), "should return nil")
+
+	SetErrorHandler(PanicOnError)
+	func() {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			assert.True(t, ok, "should panic with an error")
+			var e *ShapeMismatchError
+			assert.True(t, errors.As(err, &e), "should be a ShapeMismatchError")
+		}()
+		m.Dot(m)
+	}()
+
+	prev := SetErrorHandler(nil)
+	assert.Equal(t, reflect.ValueOf(PanicOnError).Pointer(), reflect.ValueOf(prev).Pointer(),
+		"should return the previous handler")
+	SetErrorHandler(old)
+}
@@ -0,0 +1,396 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+LU is the LU factorization of a square matrix with partial pivoting, such
+that P * A = L * U, where P is a permutation matrix, L is lower triangular
+with a unit diagonal, and U is upper triangular. An LU is created with the LU
+method of Matf64 or Matf32:
+
+	lu := m.LU()
+	x, err := lu.SolveE(b)
+
+The factorization itself succeeds for any square matrix, including singular
+ones. Solve and Inverse return a *SingularMatrixError when the estimated
+condition number of the matrix is too large for the result to be accurate,
+instead of returning a result which is mostly rounding error.
+*/
+type LU[T Float] struct {
+	lu   *Mat[T]
+	piv  []int
+	sign int
+	cond float64
+}
+
+/*
+LU computes the LU factorization of a square matrix with partial pivoting.
+The receiver is not modified.
+*/
+func (m *Mat[T]) LU() *LU[T] {
+	lu, err := m.LUE()
+	if err != nil {
+		handleErr(err)
+	}
+	return lu
+}
+
+/*
+LUE is the same as LU, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) LUE() (*LU[T], error) {
+	if m.r != m.c {
+		return nil, &ShapeMismatchError{Op: "LU()", Want: []int{m.r, m.r}, Got: []int{m.r, m.c}}
+	}
+	n := m.r
+	f := &LU[T]{lu: m.Copy(), piv: make([]int, n), sign: 1}
+	a := f.lu.vals
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(float64(a[i*n+k])) > math.Abs(float64(a[p*n+k])) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a[p*n+j], a[k*n+j] = a[k*n+j], a[p*n+j]
+			}
+			f.piv[p], f.piv[k] = f.piv[k], f.piv[p]
+			f.sign = -f.sign
+		}
+		if a[k*n+k] == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			a[i*n+k] /= a[k*n+k]
+			l := a[i*n+k]
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= l * a[k*n+j]
+			}
+		}
+	}
+	f.cond = f.condEst(m.norm1())
+	return f, nil
+}
+
+// norm1 returns the maximum absolute column sum of m.
+func (m *Mat[T]) norm1() float64 {
+	var max float64
+	for j := 0; j < m.c; j++ {
+		var s float64
+		for i := 0; i < m.r; i++ {
+			s += math.Abs(float64(m.vals[i*m.stride+j]))
+		}
+		if s > max {
+			max = s
+		}
+	}
+	return max
+}
+
+// condEst estimates the 1-norm condition number of the factorized matrix,
+// whose 1-norm is anorm, using Hager's method to estimate the 1-norm of the
+// inverse without computing it.
+func (f *LU[T]) condEst(anorm float64) float64 {
+	n := f.lu.r
+	if n == 0 {
+		return 0
+	}
+	for i := 0; i < n; i++ {
+		if f.lu.vals[i*n+i] == 0 {
+			return math.Inf(1)
+		}
+	}
+	x := make([]T, n)
+	for i := range x {
+		x[i] = 1 / T(n)
+	}
+	var est float64
+	for iter := 0; iter < 5; iter++ {
+		y := make([]T, n)
+		copy(y, x)
+		f.solveVec(y)
+		est = 0
+		z := make([]T, n)
+		for i := range y {
+			est += math.Abs(float64(y[i]))
+			z[i] = 1
+			if y[i] < 0 {
+				z[i] = -1
+			}
+		}
+		f.solveVecT(z)
+		j, zx := 0, 0.0
+		for i := range z {
+			if math.Abs(float64(z[i])) > math.Abs(float64(z[j])) {
+				j = i
+			}
+			zx += float64(z[i] * x[i])
+		}
+		if math.Abs(float64(z[j])) <= zx {
+			break
+		}
+		x = make([]T, n)
+		x[j] = 1
+	}
+	if math.IsNaN(est) {
+		return math.Inf(1)
+	}
+	return anorm * est
+}
+
+// solveVec overwrites b with the solution of A * x = b.
+func (f *LU[T]) solveVec(b []T) {
+	n := f.lu.r
+	a := f.lu.vals
+	x := make([]T, n)
+	for i := range x {
+		x[i] = b[f.piv[i]]
+	}
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			x[i] -= a[i*n+k] * x[k]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			x[i] -= a[i*n+k] * x[k]
+		}
+		x[i] /= a[i*n+i]
+	}
+	copy(b, x)
+}
+
+// solveVecT overwrites b with the solution of transpose(A) * x = b.
+func (f *LU[T]) solveVecT(b []T) {
+	n := f.lu.r
+	a := f.lu.vals
+	w := make([]T, n)
+	copy(w, b)
+	for i := 0; i < n; i++ {
+		for k := 0; k < i; k++ {
+			w[i] -= a[k*n+i] * w[k]
+		}
+		w[i] /= a[i*n+i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for k := i + 1; k < n; k++ {
+			w[i] -= a[k*n+i] * w[k]
+		}
+	}
+	for i := range w {
+		b[f.piv[i]] = w[i]
+	}
+}
+
+/*
+L returns the lower triangular factor, with ones on its diagonal.
+*/
+func (f *LU[T]) L() *Mat[T] {
+	n := f.lu.r
+	l := New[T](n, n)
+	for i := 0; i < n; i++ {
+		copy(l.vals[i*n:i*n+i], f.lu.vals[i*n:i*n+i])
+		l.vals[i*n+i] = 1
+	}
+	return l
+}
+
+/*
+U returns the upper triangular factor.
+*/
+func (f *LU[T]) U() *Mat[T] {
+	n := f.lu.r
+	u := New[T](n, n)
+	for i := 0; i < n; i++ {
+		copy(u.vals[i*n+i:(i+1)*n], f.lu.vals[i*n+i:(i+1)*n])
+	}
+	return u
+}
+
+/*
+Pivot returns the row permutation of the factorization. Row i of P * A is
+row Pivot()[i] of A.
+*/
+func (f *LU[T]) Pivot() []int {
+	p := make([]int, len(f.piv))
+	copy(p, f.piv)
+	return p
+}
+
+/*
+Cond returns the estimated 1-norm condition number of the factorized matrix.
+It is +Inf if the matrix is exactly singular.
+*/
+func (f *LU[T]) Cond() float64 {
+	return f.cond
+}
+
+/*
+Det returns the determinant of the factorized matrix. The determinant of a
+singular matrix is 0, and no error is reported. For large matrices the
+determinant easily overflows, in which case LogDet should be used instead.
+*/
+func (f *LU[T]) Det() T {
+	n := f.lu.r
+	d := T(f.sign)
+	for i := 0; i < n; i++ {
+		d *= f.lu.vals[i*n+i]
+	}
+	return d
+}
+
+/*
+LogDet returns the natural logarithm of the absolute value of the
+determinant of the factorized matrix, along with its sign, which is 1, -1 or
+0. The determinant is sign * math.Exp(logDet).
+*/
+func (f *LU[T]) LogDet() (logDet float64, sign float64) {
+	n := f.lu.r
+	sign = float64(f.sign)
+	for i := 0; i < n; i++ {
+		v := float64(f.lu.vals[i*n+i])
+		if v == 0 {
+			return math.Inf(-1), 0
+		}
+		if v < 0 {
+			sign = -sign
+		}
+		logDet += math.Log(math.Abs(v))
+	}
+	return logDet, sign
+}
+
+// checkCond returns a *SingularMatrixError if the condition number of the
+// factorized matrix is too large for the element type.
+func (f *LU[T]) checkCond(op string) error {
+	eps := epsilon[T]()
+	if math.IsInf(f.cond, 1) || f.cond*eps >= 1 {
+		return &SingularMatrixError{Op: op, Cond: f.cond}
+	}
+	return nil
+}
+
+/*
+Solve returns the solution x of A * x = b, where A is the factorized matrix,
+and b has as many rows as A. Each column of x is the solution for the
+corresponding column of b.
+*/
+func (f *LU[T]) Solve(b *Mat[T]) *Mat[T] {
+	x, err := f.SolveE(b)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+SolveE is the same as Solve, except that it returns an error instead of
+exiting the program.
+*/
+func (f *LU[T]) SolveE(b *Mat[T]) (*Mat[T], error) {
+	if b.r != f.lu.r {
+		return nil, &ShapeMismatchError{
+			Op:   "Solve()",
+			Want: []int{f.lu.r, b.c},
+			Got:  []int{b.r, b.c},
+		}
+	}
+	if err := f.checkCond("Solve()"); err != nil {
+		return nil, err
+	}
+	x := New[T](b.r, b.c)
+	col := make([]T, b.r)
+	for j := 0; j < b.c; j++ {
+		for i := 0; i < b.r; i++ {
+			col[i] = b.vals[i*b.stride+j]
+		}
+		f.solveVec(col)
+		for i := 0; i < b.r; i++ {
+			x.vals[i*x.c+j] = col[i]
+		}
+	}
+	return x, nil
+}
+
+/*
+Inverse returns the inverse of the factorized matrix.
+*/
+func (f *LU[T]) Inverse() *Mat[T] {
+	inv, err := f.InverseE()
+	if err != nil {
+		handleErr(err)
+	}
+	return inv
+}
+
+/*
+InverseE is the same as Inverse, except that it returns an error instead of
+exiting the program.
+*/
+func (f *LU[T]) InverseE() (*Mat[T], error) {
+	if err := f.checkCond("Inverse()"); err != nil {
+		return nil, err
+	}
+	return f.SolveE(I[T](f.lu.r))
+}
+
+/*
+Det returns the determinant of a square matrix, computed through its LU
+factorization.
+*/
+func (m *Mat[T]) Det() T {
+	d, err := m.DetE()
+	if err != nil {
+		handleErr(err)
+	}
+	return d
+}
+
+/*
+DetE is the same as Det, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) DetE() (T, error) {
+	if m.r != m.c {
+		return 0, &ShapeMismatchError{Op: "Det()", Want: []int{m.r, m.r}, Got: []int{m.r, m.c}}
+	}
+	f, _ := m.LUE()
+	return f.Det(), nil
+}
+
+/*
+Inverse returns the inverse of a square matrix as a new matrix, computed
+through its LU factorization. The receiver is not modified.
+*/
+func (m *Mat[T]) Inverse() *Mat[T] {
+	n, err := m.InverseE()
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+InverseE is the same as Inverse, except that it returns an error instead of
+exiting the program. A *SingularMatrixError is returned for a singular or
+nearly singular matrix.
+*/
+func (m *Mat[T]) InverseE() (*Mat[T], error) {
+	if m.r != m.c {
+		return nil, &ShapeMismatchError{
+			Op:   "Inverse()",
+			Want: []int{m.r, m.r},
+			Got:  []int{m.r, m.c},
+		}
+	}
+	f, _ := m.LUE()
+	return f.InverseE()
+}
@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLUf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, 1, 1},
+		{4, -6, 0},
+		{-2, 7, 2},
+	})
+	lu := m.LU()
+	pa := New[float64](3, 3)
+	for i, p := range lu.Pivot() {
+		pa.SetRow(i, m.Row(p).ToSlice1D())
+	}
+	prod := lu.L().Dot(lu.U())
+	for i := range prod.vals {
+		assert.InDelta(t, pa.vals[i], prod.vals[i], 1e-12, "P*A should equal L*U")
+	}
+	assert.InDelta(t, -16.0, lu.Det(), 1e-12, "should be equal")
+	assert.InDelta(t, -16.0, m.Det(), 1e-12, "should be equal")
+	logDet, sign := lu.LogDet()
+	assert.InDelta(t, math.Log(16), logDet, 1e-12, "should be equal")
+	assert.Equal(t, -1.0, sign, "should be equal")
+
+	b := Matf64FromData([]float64{5, -2, 9}, 3)
+	x := lu.Solve(b)
+	assert.InDeltaSlice(t, []float64{1, 1, 2}, x.vals, 1e-12, "should be equal")
+	inv := m.Inverse()
+	id := m.Dot(inv)
+	for i := range id.vals {
+		assert.InDelta(t, I[float64](3).vals[i], id.vals[i], 1e-12, "should be identity")
+	}
+	assert.True(t, lu.Cond() >= 1, "condition number is at least 1")
+}
+
+func TestLUSingularf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matf64FromData([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+	_, err := m.InverseE()
+	var e *SingularMatrixError
+	assert.True(t, errors.As(err, &e), "should be singular")
+	_, err = m.LU().SolveE(Newf64(3, 1))
+	assert.True(t, errors.As(err, &e), "should be singular")
+	assert.InDelta(t, 0.0, m.Det(), 1e-12, "should be equal")
+
+	z := Newf64(2)
+	assert.True(t, math.IsInf(z.LU().Cond(), 1), "should be singular")
+	_, sign := z.LU().LogDet()
+	assert.Equal(t, 0.0, sign, "should be equal")
+	assert.Panics(t, func() { Newf64(2, 3).LU() }, "not square")
+	assert.Panics(t, func() { m.LU().Solve(Newf64(2, 1)) }, "wrong shape")
+}
+
+func TestLUf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{4, 3},
+		{6, 3},
+	})
+	assert.InDelta(t, -6.0, float64(m.Det()), 1e-5, "should be equal")
+	inv := m.Inverse()
+	assert.InDeltaSlice(t, []float32{-0.5, 0.5, 1, -2.0 / 3}, inv.vals, 1e-5, "should be equal")
+}
@@ -0,0 +1,429 @@
+package matrix
+
+import (
+	"reflect"
+	"strings"
+)
+
+/*
+Mask is a matrix of booleans, with one element for each element of the Mat
+it was made from. Masks are created by comparing the elements of a Mat to a
+value or to another Mat, with methods such as Gt and IsNaN:
+
+	m := matrix.Matf64FromData([]float64{1, 5, 2, 7})
+	mask := m.Gt(3.0)     // [false true false true]
+	mask.Count()          // 2
+	m.Select(mask)        // [5 7]
+	m.SetWhere(mask, 0.0) // m is now [1 0 2 0]
+
+Masks can be combined with And, Or and Not. Like the arithmetic methods of
+Mat, these modify the receiver and return it so that calls can be chained:
+
+	inRange := m.Ge(1.0).And(m.Le(2.0))
+*/
+type Mask struct {
+	r, c int
+	vals []bool
+}
+
+func newMask(r, c int) *Mask {
+	return &Mask{r: r, c: c, vals: make([]bool, r*c)}
+}
+
+/*
+Shape returns the number of rows and columns of a Mask.
+*/
+func (k *Mask) Shape() (int, int) {
+	return k.r, k.c
+}
+
+/*
+Get returns the element of a Mask at the passed row and column.
+*/
+func (k *Mask) Get(r, c int) bool {
+	return k.vals[r*k.c+c]
+}
+
+/*
+ToSlice2D returns the elements of a Mask as a 2D slice of booleans.
+*/
+func (k *Mask) ToSlice2D() [][]bool {
+	s := make([][]bool, k.r)
+	for i := range s {
+		s[i] = append([]bool(nil), k.vals[i*k.c:(i+1)*k.c]...)
+	}
+	return s
+}
+
+/*
+Count returns the number of true elements in a Mask.
+*/
+func (k *Mask) Count() int {
+	n := 0
+	for _, v := range k.vals {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+/*
+Not negates each element of a Mask, and returns the Mask.
+*/
+func (k *Mask) Not() *Mask {
+	for i := range k.vals {
+		k.vals[i] = !k.vals[i]
+	}
+	return k
+}
+
+/*
+And sets each element of the receiver to true if both it and the matching
+element of the passed Mask are true. Both masks must have the same shape.
+*/
+func (k *Mask) And(n *Mask) *Mask {
+	if err := k.AndE(n); err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+AndE is the same as And, except that it returns an error instead of exiting
+the program.
+*/
+func (k *Mask) AndE(n *Mask) error {
+	return k.combine("And()", n, func(a, b bool) bool { return a && b })
+}
+
+/*
+Or sets each element of the receiver to true if either it or the matching
+element of the passed Mask is true. Both masks must have the same shape.
+*/
+func (k *Mask) Or(n *Mask) *Mask {
+	if err := k.OrE(n); err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+OrE is the same as Or, except that it returns an error instead of exiting the
+program.
+*/
+func (k *Mask) OrE(n *Mask) error {
+	return k.combine("Or()", n, func(a, b bool) bool { return a || b })
+}
+
+/*
+String returns the string representation of a Mask, in the same layout as
+that of a Mat.
+*/
+func (k *Mask) String() string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < k.r; i++ {
+		if i > 0 {
+			b.WriteString("\n ")
+		}
+		b.WriteString("[")
+		for j := 0; j < k.c; j++ {
+			if j > 0 {
+				b.WriteString(",\t")
+			}
+			if k.vals[i*k.c+j] {
+				b.WriteString("true")
+			} else {
+				b.WriteString("false")
+			}
+		}
+		b.WriteString("]")
+	}
+	b.WriteString("]\n")
+	return b.String()
+}
+
+func (k *Mask) combine(op string, n *Mask, f func(a, b bool) bool) error {
+	if k.r != n.r || k.c != n.c {
+		return &ShapeMismatchError{Op: op, Want: []int{k.r, k.c}, Got: []int{n.r, n.c}}
+	}
+	for i := range k.vals {
+		k.vals[i] = f(k.vals[i], n.vals[i])
+	}
+	return nil
+}
+
+/*
+Gt returns a Mask which is true where the elements of a Mat are greater than
+the passed value. The value can be a float64, a float32, or a Mat which has
+the same shape as the receiver or can be broadcast to it, as described in
+Add.
+*/
+func (m *Mat[T]) Gt(floatOrMat interface{}) *Mask {
+	k, err := m.GtE(floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+GtE is the same as Gt, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) GtE(floatOrMat interface{}) (*Mask, error) {
+	return m.compare("Gt()", floatOrMat, func(a, b T) bool { return a > b })
+}
+
+/*
+Ge returns a Mask which is true where the elements of a Mat are greater than
+or equal to the passed value, in the same way as Gt.
+*/
+func (m *Mat[T]) Ge(floatOrMat interface{}) *Mask {
+	k, err := m.GeE(floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+GeE is the same as Ge, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) GeE(floatOrMat interface{}) (*Mask, error) {
+	return m.compare("Ge()", floatOrMat, func(a, b T) bool { return a >= b })
+}
+
+/*
+Lt returns a Mask which is true where the elements of a Mat are less than
+the passed value, in the same way as Gt.
+*/
+func (m *Mat[T]) Lt(floatOrMat interface{}) *Mask {
+	k, err := m.LtE(floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+LtE is the same as Lt, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) LtE(floatOrMat interface{}) (*Mask, error) {
+	return m.compare("Lt()", floatOrMat, func(a, b T) bool { return a < b })
+}
+
+/*
+Le returns a Mask which is true where the elements of a Mat are less than or
+equal to the passed value, in the same way as Gt.
+*/
+func (m *Mat[T]) Le(floatOrMat interface{}) *Mask {
+	k, err := m.LeE(floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+LeE is the same as Le, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) LeE(floatOrMat interface{}) (*Mask, error) {
+	return m.compare("Le()", floatOrMat, func(a, b T) bool { return a <= b })
+}
+
+/*
+Eq returns a Mask which is true where the elements of a Mat are exactly equal
+to the passed value, in the same way as Gt. Since NaN is not equal to
+anything, use IsNaN to find NaN values.
+*/
+func (m *Mat[T]) Eq(floatOrMat interface{}) *Mask {
+	k, err := m.EqE(floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return k
+}
+
+/*
+EqE is the same as Eq, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) EqE(floatOrMat interface{}) (*Mask, error) {
+	return m.compare("Eq()", floatOrMat, func(a, b T) bool { return a == b })
+}
+
+/*
+IsNaN returns a Mask which is true where the elements of a Mat are NaN.
+*/
+func (m *Mat[T]) IsNaN() *Mask {
+	k := newMask(m.r, m.c)
+	m.each(func(off int, run []T) {
+		for i, v := range run {
+			k.vals[off+i] = v != v
+		}
+	})
+	return k
+}
+
+/*
+Where returns a new Mat which holds the elements of the receiver where the
+passed Mask is true, and the passed value elsewhere. The value can be a
+float64, a float32, or a Mat which can be broadcast to the receiver as
+described in Add. For example, to replace the NaN values of m with zero
+without modifying m:
+
+	n := m.Where(m.IsNaN().Not(), 0.0)
+*/
+func (m *Mat[T]) Where(mask *Mask, floatOrMat interface{}) *Mat[T] {
+	n, err := m.WhereE(mask, floatOrMat)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+WhereE is the same as Where, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) WhereE(mask *Mask, floatOrMat interface{}) (*Mat[T], error) {
+	n := m.Copy()
+	if err := n.setWhere("Where()", mask, floatOrMat, false); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+/*
+SetWhere sets the elements of the receiver where the passed Mask is true to
+the passed value, which can be a float64, a float32, or a Mat which can be
+broadcast to the receiver as described in Add. It returns the receiver:
+
+	m.SetWhere(m.Lt(0.0), 0.0) // Set the negative elements of m to zero.
+*/
+func (m *Mat[T]) SetWhere(mask *Mask, floatOrMat interface{}) *Mat[T] {
+	if err := m.SetWhereE(mask, floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetWhereE is the same as SetWhere, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) SetWhereE(mask *Mask, floatOrMat interface{}) error {
+	return m.setWhere("SetWhere()", mask, floatOrMat, true)
+}
+
+/*
+Select returns the elements of a Mat where the passed Mask is true, in row
+major order, as a 1 by n Mat. The Mask must have the same shape as the Mat.
+*/
+func (m *Mat[T]) Select(mask *Mask) *Mat[T] {
+	n, err := m.SelectE(mask)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+SelectE is the same as Select, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) SelectE(mask *Mask) (*Mat[T], error) {
+	if err := m.checkMask("Select()", mask); err != nil {
+		return nil, err
+	}
+	n := New[T](1, mask.Count())
+	n.vals = n.vals[:0]
+	m.each(func(off int, run []T) {
+		for i, v := range run {
+			if mask.vals[off+i] {
+				n.vals = append(n.vals, v)
+			}
+		}
+	})
+	return n, nil
+}
+
+func (m *Mat[T]) checkMask(op string, mask *Mask) error {
+	if m.r != mask.r || m.c != mask.c {
+		return &ShapeMismatchError{Op: op, Want: []int{m.r, m.c}, Got: []int{mask.r, mask.c}}
+	}
+	return nil
+}
+
+// setWhere sets the elements of m to the matching values of floatOrMat where
+// mask equals want.
+func (m *Mat[T]) setWhere(op string, mask *Mask, floatOrMat interface{}, want bool) error {
+	if err := m.checkMask(op, mask); err != nil {
+		return err
+	}
+	// n is copied in case that it is a view into m.
+	if n, ok := floatOrMat.(*Mat[T]); ok && n != m {
+		floatOrMat = n.Copy()
+	}
+	at, err := m.operand(op, floatOrMat)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.row(i)
+		for j := range row {
+			if mask.vals[i*m.c+j] == want {
+				row[j] = at(i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// compare returns the Mask of the elements of m for which f is true, when
+// compared with the matching values of floatOrMat.
+func (m *Mat[T]) compare(op string, floatOrMat interface{}, f func(a, b T) bool) (*Mask, error) {
+	at, err := m.operand(op, floatOrMat)
+	if err != nil {
+		return nil, err
+	}
+	k := newMask(m.r, m.c)
+	for i := 0; i < m.r; i++ {
+		for j, v := range m.row(i) {
+			k.vals[i*m.c+j] = f(v, at(i, j))
+		}
+	}
+	return k, nil
+}
+
+// operand returns a function giving the value of floatOrMat which matches
+// the element of m at row i and column j, broadcasting floatOrMat in the
+// same way as broadcast.
+func (m *Mat[T]) operand(op string, floatOrMat interface{}) (func(i, j int) T, error) {
+	if x, ok := scalar[T](floatOrMat); ok {
+		return func(int, int) T { return x }, nil
+	}
+	n, ok := floatOrMat.(*Mat[T])
+	if !ok {
+		return nil, &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(floatOrMat)}
+	}
+	switch {
+	case n.r == m.r && n.c == m.c:
+		return func(i, j int) T { return n.vals[i*n.stride+j] }, nil
+	case n.r == 1 && n.c == 1:
+		x := n.vals[0]
+		return func(int, int) T { return x }, nil
+	case n.isRowVector() && n.c == m.c:
+		v := n.ToSlice1D()
+		return func(_, j int) T { return v[j] }, nil
+	case n.isColVector() && n.r == m.r:
+		v := n.ToSlice1D()
+		return func(i, _ int) T { return v[i] }, nil
+	}
+	return nil, &ShapeMismatchError{Op: op, Want: []int{m.r, m.c}, Got: []int{n.r, n.c}}
+}
@@ -0,0 +1,93 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 5, 3},
+		{7, 3, 2},
+	})
+	assert.Equal(t, []bool{false, true, false, true, false, false}, m.Gt(3.0).vals, "Gt")
+	assert.Equal(t, []bool{false, true, true, true, true, false}, m.Ge(3.0).vals, "Ge")
+	assert.Equal(t, []bool{true, false, false, false, false, true}, m.Lt(3.0).vals, "Lt")
+	assert.Equal(t, []bool{true, false, true, false, true, true}, m.Le(3.0).vals, "Le")
+	assert.Equal(t, []bool{false, false, true, false, true, false}, m.Eq(3.0).vals, "Eq")
+
+	n := Matf64FromData([][]float64{
+		{1, 6, 2},
+		{7, 0, 9},
+	})
+	assert.Equal(t, []bool{true, false, false, true, false, false}, m.Eq(n).vals, "same shape")
+	row := Matf64FromData([]float64{2, 4, 2})
+	assert.Equal(t, []bool{false, true, true, true, false, false}, m.Gt(row).vals, "row vector")
+	col := Matf64FromData([]float64{3, 2}, 2)
+	assert.Equal(t, []bool{true, false, false, false, false, false}, m.Lt(col).vals, "col vector")
+
+	v := rangeMatf64(3, 3).View(1, 3, 0, 2)
+	assert.Equal(t, [][]bool{{false, true}, {true, true}}, v.Gt(3.0).ToSlice2D(), "view")
+
+	x := Matf64FromData([]float64{math.NaN(), 1, math.Inf(1)})
+	assert.Equal(t, []bool{true, false, false}, x.IsNaN().vals, "IsNaN")
+	assert.Equal(t, 0, x.Eq(math.NaN()).Count(), "NaN is not equal to NaN")
+
+	_, err := m.GtE(Newf64(3, 3))
+	assert.NotNil(t, err, "shapes mismatch")
+	_, err = m.EqE("3")
+	assert.NotNil(t, err, "unsupported type")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.Lt(Newf64(1, 2)) }, "shapes mismatch")
+}
+
+func TestMaskf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 5, 2, 7, 3})
+	k := m.Ge(2.0).And(m.Le(5.0))
+	assert.Equal(t, []bool{false, true, true, false, true}, k.vals, "And")
+	assert.Equal(t, 3, k.Count(), "Count")
+	assert.True(t, k.Get(0, 4), "should be true")
+	r, c := k.Shape()
+	assert.Equal(t, []int{1, 5}, []int{r, c}, "should be equal")
+	assert.Equal(t, []bool{true, false, false, true, false}, k.Not().vals, "Not")
+	assert.Equal(t, []bool{true, false, true, true, false}, k.Or(m.Eq(2.0)).vals, "Or")
+	assert.Equal(t, "[[true,\tfalse,\ttrue,\ttrue,\tfalse]]\n", k.String(), "String")
+
+	assert.NotNil(t, k.AndE(newMask(5, 1)), "shapes mismatch")
+	assert.NotNil(t, k.OrE(newMask(1, 4)), "shapes mismatch")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { k.And(newMask(2, 2)) }, "shapes mismatch")
+}
+
+func TestWheref32(t *testing.T) {
+	t.Helper()
+	nan := float32(math.NaN())
+	m := Matf32FromData([][]float32{
+		{-1, nan, 3},
+		{4, -5, nan},
+	})
+	n := m.Where(m.IsNaN().Not(), 0.0)
+	assert.Equal(t, []float32{-1, 0, 3, 4, -5, 0}, n.vals, "Where")
+	assert.True(t, math.IsNaN(float64(m.vals[1])), "m should not change")
+
+	n.SetWhere(n.Lt(0.0), Matf32FromData([]float32{10, 20}, 2))
+	assert.Equal(t, []float32{10, 0, 3, 4, 20, 0}, n.vals, "SetWhere")
+	assert.Equal(t, []float32{10, 3, 4, 20}, n.Select(n.Gt(0.0)).vals, "Select")
+	r, c := n.Select(n.Gt(100.0)).Shape()
+	assert.Equal(t, []int{1, 0}, []int{r, c}, "nothing selected")
+
+	// Where with a view of the same matrix.
+	s := Matf32FromData([]float32{0, 1, 2, 3}, 2, 2)
+	s.SetWhere(s.Gt(0.0), s.View(0, 1, 0, 2))
+	assert.Equal(t, []float32{0, 1, 0, 1}, s.vals, "broadcast view of itself")
+
+	_, err := m.SelectE(newMask(3, 2))
+	assert.NotNil(t, err, "shapes mismatch")
+	assert.NotNil(t, m.SetWhereE(newMask(2, 3), Newf32(3, 3)), "shapes mismatch")
+	_, err = m.WhereE(newMask(2, 2), 0.0)
+	assert.NotNil(t, err, "shapes mismatch")
+}
@@ -0,0 +1,1960 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/chewxy/vecf32"
+	"github.com/chewxy/vecf64"
+)
+
+/*
+Float is the set of element types which a Mat can hold.
+*/
+type Float interface {
+	~float32 | ~float64
+}
+
+/*
+Mat is the main struct of this library. Mat is a essentially a 1D slice
+(a []T) that contains two integers, representing rows and columns,
+which allow it to behave as if it was a 2D slice. This allows for higher
+performance and flexibility for the users of this library, at the expense
+of some bookkeeping that is done here.
+
+Matf64 and Matf32 are the instances of Mat for float64 and float32, and all
+of the methods below are available on both of them.
+
+A Mat may also be a view into a block of another Mat, as returned by View,
+in which case it shares the values of that Mat. The rows of a view are
+stride values apart in vals, rather than c values apart.
+
+The fields of this struct are not directly accessible, and they may only
+change by the use of the various methods in this library.
+*/
+type Mat[T Float] struct {
+	r, c   int
+	stride int
+	vals   []T
+}
+
+/*
+New is the primary constructor for the generic "Mat" object. It behaves
+exactly as Newf64 and Newf32, for any element type T. For example:
+
+	m := matrix.New[float64](2, 3)
+*/
+func New[T Float](dims ...int) *Mat[T] {
+	m, err := newE[T]("New()", dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+NewE is the same as New, except that it returns an error instead of exiting
+the program.
+*/
+func NewE[T Float](dims ...int) (*Mat[T], error) {
+	return newE[T]("New()", dims)
+}
+
+func newE[T Float](op string, dims []int) (*Mat[T], error) {
+	m := &Mat[T]{}
+	switch len(dims) {
+	case 0:
+		m = &Mat[T]{
+			vals: make([]T, 0),
+		}
+	case 1:
+		if err := checkDims[T](op, dims[0], dims[0]); err != nil {
+			return nil, err
+		}
+		m = &Mat[T]{
+			r:      dims[0],
+			c:      dims[0],
+			stride: dims[0],
+			vals:   make([]T, dims[0]*dims[0], 2*dims[0]*dims[0]),
+		}
+	case 2:
+		if err := checkDims[T](op, dims[0], dims[1]); err != nil {
+			return nil, err
+		}
+		m = &Mat[T]{
+			r:      dims[0],
+			c:      dims[1],
+			stride: dims[1],
+			vals:   make([]T, dims[0]*dims[1], 2*dims[0]*dims[1]),
+		}
+	default:
+		s := "expected 0 to 2 arguments, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	}
+	return m, nil
+}
+
+// maxBytes is the largest allocation made by newE, which is below the
+// largest that the runtime supports: 1<<47 bytes on 64-bit platforms, and
+// 1<<30 bytes on 32-bit ones.
+const maxBytes = 1 << (30 + 17*(strconv.IntSize/64))
+
+// checkDims returns an *ArgumentError if r or c is negative, or if an r by c
+// matrix of T, with room to grow to twice its size, would not fit in
+// maxBytes. It is shared by the constructors of Mat, IntMat and CMat.
+func checkDims[T any](op string, r, c int) error {
+	if r < 0 || c < 0 {
+		s := "the dimensions cannot be negative, but %d by %d was received"
+		return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, r, c)}
+	}
+	var x T
+	if c > 0 && r > maxBytes/2/int(unsafe.Sizeof(x))/c {
+		s := "a %d by %d matrix is too large to allocate"
+		return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, r, c)}
+	}
+	return nil
+}
+
+/*
+I returns the x by x identity matrix.
+*/
+func I[T Float](x int) *Mat[T] {
+	m := New[T](x)
+	for i := 0; i < x; i++ {
+		m.vals[i*x+i] = 1.0
+	}
+	return m
+}
+
+/*
+MatFromData is the generic counterpart of Matf64FromData and Matf32FromData.
+It accepts a []T or a [][]T, and the same dimensions as those functions.
+*/
+func MatFromData[T Float](oneOrTwoDSlice interface{}, dims ...int) *Mat[T] {
+	m, err := matFromDataE[T]("MatFromData()", oneOrTwoDSlice, dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+MatFromDataE is the same as MatFromData, except that it returns an error
+instead of exiting the program.
+*/
+func MatFromDataE[T Float](oneOrTwoDSlice interface{}, dims ...int) (*Mat[T], error) {
+	return matFromDataE[T]("MatFromData()", oneOrTwoDSlice, dims)
+}
+
+func matFromDataE[T Float](op string, oneOrTwoDSlice interface{}, dims []int) (*Mat[T], error) {
+	switch v := oneOrTwoDSlice.(type) {
+	case []T:
+		return matFromOneDSliceHelper(op, v, dims)
+	case [][]T:
+		return matFromTwoDSliceHelper(op, v, dims)
+	default:
+		return nil, &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(v)}
+	}
+}
+
+func matFromOneDSliceHelper[T Float](op string, v []T, dims []int) (*Mat[T], error) {
+	m := New[T]()
+	switch len(dims) {
+	case 0:
+		m.vals = make([]T, len(v), len(v)*2)
+		copy(m.vals, v)
+		m.r, m.c = 1, len(v)
+		m.stride = m.c
+	case 1:
+		if dims[0] != len(v) {
+			return nil, &ShapeMismatchError{
+				Op:   op,
+				Want: []int{dims[0]},
+				Got:  []int{len(v)},
+			}
+		}
+		m.vals = make([]T, dims[0], dims[0]*2)
+		copy(m.vals, v)
+		m.r, m.c = dims[0], 1
+		m.stride = m.c
+	case 2:
+		if err := checkDims[T](op, dims[0], dims[1]); err != nil {
+			return nil, err
+		}
+		if dims[0]*dims[1] != len(v) {
+			return nil, &ShapeMismatchError{
+				Op:   op,
+				Want: []int{dims[0], dims[1]},
+				Got:  []int{len(v)},
+			}
+		}
+		m.vals = make([]T, dims[0]*dims[1], dims[0]*dims[1]*2)
+		copy(m.vals, v)
+		m.r, m.c = dims[0], dims[1]
+		m.stride = m.c
+	default:
+		s := "expected 0 to 2 ints, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	}
+	return m, nil
+}
+
+func matFromTwoDSliceHelper[T Float](op string, v [][]T, dims []int) (*Mat[T], error) {
+	if len(v) == 0 {
+		return nil, &ArgumentError{Op: op, Msg: "the passed [][] slice is empty"}
+	}
+	for i := range v {
+		if len(v[i]) != len(v[0]) {
+			s := "row %d has %d values, but row 0 has %d"
+			return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, i, len(v[i]), len(v[0]))}
+		}
+	}
+	m := New[T]()
+	switch len(dims) {
+	case 0:
+		m.vals = make([]T, len(v)*len(v[0]), len(v)*len(v[0])*2)
+		for i := range v {
+			for j := range v[i] {
+				m.vals[i*len(v[0])+j] = v[i][j]
+			}
+		}
+		m.r, m.c = len(v), len(v[0])
+		m.stride = m.c
+	case 1:
+		if err := checkDims[T](op, dims[0], dims[0]); err != nil {
+			return nil, err
+		}
+		if dims[0]*dims[0] != len(v)*len(v[0]) {
+			return nil, &ShapeMismatchError{
+				Op:   op,
+				Want: []int{dims[0], dims[0]},
+				Got:  []int{len(v), len(v[0])},
+			}
+		}
+		m.vals = make([]T, dims[0]*dims[0], dims[0]*dims[0]*2)
+		for i := range v {
+			for j := range v[i] {
+				m.vals[i*len(v[0])+j] = v[i][j]
+			}
+		}
+		m.r, m.c = dims[0], dims[0]
+		m.stride = m.c
+	case 2:
+		if dims[0] != len(v) || dims[1] != len(v[0]) {
+			return nil, &ShapeMismatchError{
+				Op:   op,
+				Want: []int{dims[0], dims[1]},
+				Got:  []int{len(v), len(v[0])},
+			}
+		}
+		m.vals = make([]T, dims[0]*dims[1], dims[0]*dims[1]*2)
+		for i := range v {
+			for j := range v[i] {
+				m.vals[i*len(v[0])+j] = v[i][j]
+			}
+		}
+		m.r, m.c = len(v), len(v[0])
+		m.stride = m.c
+	default:
+		s := "expected 0 to 2 ints, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	} // switch len(dims) for case [][]T
+	return m, nil
+}
+
+/*
+MatFromCSV is the generic counterpart of Matf64FromCSV and Matf32FromCSV.
+*/
+func MatFromCSV[T Float](filename string) *Mat[T] {
+	m, err := matFromCSVE[T]("MatFromCSV()", filename)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+MatFromCSVE is the same as MatFromCSV, except that it returns an error
+instead of exiting the program.
+*/
+func MatFromCSVE[T Float](filename string) (*Mat[T], error) {
+	return matFromCSVE[T]("MatFromCSV()", filename)
+}
+
+func matFromCSVE[T Float](op, filename string) (*Mat[T], error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	defer f.Close()
+	m, _, err := readCSVE[T](op, f, nil)
+	return m, err
+}
+
+/*
+RandMat is the generic counterpart of RandMatf64 and RandMatf32.
+*/
+func RandMat[T Float](r, c int, args ...T) *Mat[T] {
+	m, err := randMatE("RandMat()", r, c, args)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+RandMatE is the same as RandMat, except that it returns an error instead of
+exiting the program.
+*/
+func RandMatE[T Float](r, c int, args ...T) (*Mat[T], error) {
+	return randMatE("RandMat()", r, c, args)
+}
+
+func randMatE[T Float](op string, r, c int, args []T) (*Mat[T], error) {
+	m, err := newE[T](op, []int{r, c})
+	if err != nil {
+		return nil, err
+	}
+	switch len(args) {
+	case 0:
+		for i := 0; i < m.r*m.c; i++ {
+			m.vals[i] = randFloat[T]()
+		}
+	case 1:
+		to := args[0]
+		for i := 0; i < m.r*m.c; i++ {
+			m.vals[i] = randFloat[T]() * to
+		}
+	case 2:
+		from := args[0]
+		to := args[1]
+		if !(from < to) {
+			s := "the first argument, %f, is not less than the second, %f"
+			return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, from, to)}
+		}
+		for i := 0; i < m.r*m.c; i++ {
+			m.vals[i] = randFloat[T]()*(to-from) + from
+		}
+	default:
+		s := "expected 0 to 2 arguments, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(args))}
+	}
+	return m, nil
+}
+
+// randFloat returns a random value in [0, 1) with the precision of T.
+func randFloat[T Float]() T {
+	if bitSize[T]() == 32 {
+		return T(rand.Float32())
+	}
+	return T(rand.Float64())
+}
+
+// bitSize returns the number of bits of T, as expected by the strconv package.
+func bitSize[T Float]() int {
+	var x T
+	return int(unsafe.Sizeof(x)) * 8
+}
+
+// epsilon returns the machine epsilon of T.
+func epsilon[T Float]() float64 {
+	if bitSize[T]() == 32 {
+		return math.Pow(2, -23)
+	}
+	return math.Pow(2, -52)
+}
+
+// scalar converts a float64, float32 or T held in an interface{} to T.
+func scalar[T Float](v interface{}) (T, bool) {
+	switch x := v.(type) {
+	case T:
+		return x, true
+	case float64:
+		return T(x), true
+	case float32:
+		return T(x), true
+	}
+	return 0, false
+}
+
+/*
+Reshape changes the row and the columns of the mat object as long as the total
+number of values contained in the mat object remains constant. The order and
+the values of the mat does not change with this function. A view whose rows
+are not adjacent in its parent is materialized first, see Materialize.
+*/
+func (m *Mat[T]) Reshape(rows, cols int) *Mat[T] {
+	if err := m.ReshapeE(rows, cols); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReshapeE is the same as Reshape, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) ReshapeE(rows, cols int) error {
+	if rows*cols != m.r*m.c {
+		return &ShapeMismatchError{Op: "Reshape()", Want: []int{m.r * m.c}, Got: []int{rows * cols}}
+	}
+	if !m.contiguous() {
+		m.Materialize()
+	}
+	m.r = rows
+	m.c = cols
+	m.stride = cols
+	return nil
+}
+
+/*
+Shape returns the number of rows and columns of a mat object.
+*/
+func (m *Mat[T]) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+ToSlice1D returns the values contained in a mat object as a 1D slice.
+*/
+func (m *Mat[T]) ToSlice1D() []T {
+	s := make([]T, m.r*m.c)
+	m.each(func(off int, run []T) {
+		copy(s[off:], run)
+	})
+	return s
+}
+
+/*
+ToSlice2D returns the values of a mat object as a 2D slice.
+*/
+func (m *Mat[T]) ToSlice2D() [][]T {
+	s := make([][]T, m.r)
+	for i := range s {
+		s[i] = make([]T, m.c)
+		for j := range s[i] {
+			s[i][j] = m.vals[i*m.stride+j]
+		}
+	}
+	return s
+}
+
+/*
+ToSlice1Df64 returns the values contained in a mat object as a 1D slice of
+float64s.
+*/
+func (m *Mat[T]) ToSlice1Df64() []float64 {
+	s := make([]float64, m.r*m.c)
+	m.each(func(off int, run []T) {
+		for i, v := range run {
+			s[off+i] = float64(v)
+		}
+	})
+	return s
+}
+
+/*
+ToSlice2Df64 returns the values of a mat object as a 2D slice of float64s.
+*/
+func (m *Mat[T]) ToSlice2Df64() [][]float64 {
+	s := make([][]float64, m.r)
+	for i := range s {
+		s[i] = make([]float64, m.c)
+		for j := range s[i] {
+			s[i][j] = float64(m.vals[i*m.stride+j])
+		}
+	}
+	return s
+}
+
+/*
+ToCSV creates a file with the passed name, and writes the content of a mat
+object to it, by putting each row in a single comma separated line. The
+number of entries in each line is equal to the columns of the mat object.
+The values are written in scientific notation with 14 digits after the
+decimal point, and the last line does not end with a newline. To choose the
+format, the delimiter or a header, see WriteCSV.
+*/
+func (m *Mat[T]) ToCSV(fileName string) {
+	if err := m.ToCSVE(fileName); err != nil {
+		handleErr(err)
+	}
+}
+
+/*
+ToCSVE is the same as ToCSV, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) ToCSVE(fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("In %s, %w", "ToCSV()", err)
+	}
+	// ToCSV has never ended its output with a newline, unlike WriteCSV.
+	nl := &noFinalNewline{w: f}
+	err = m.writeCSV("ToCSV()", nl, &CSVWriteOptions{Format: 'e', Prec: 14})
+	if cerr := f.Close(); err == nil && cerr != nil {
+		return fmt.Errorf("In %s, %w", "ToCSV()", cerr)
+	}
+	return err
+}
+
+/*
+Get returns the value stored in the given row and column.
+*/
+func (m *Mat[T]) Get(r, c int) T {
+	return m.vals[r*m.stride+c]
+}
+
+/*
+Set sets the value of a mat at a given row and column to a given
+value.
+*/
+func (m *Mat[T]) Set(r, c int, val T) *Mat[T] {
+	m.vals[r*m.stride+c] = val
+	return m
+}
+
+/*
+SetAll sets all values of a mat to the passed float64 value, converted to the
+element type of the mat.
+*/
+func (m *Mat[T]) SetAll(val float64) *Mat[T] {
+	x := T(val)
+	m.each(func(_ int, run []T) {
+		for i := range run {
+			run[i] = x
+		}
+	})
+	return m
+}
+
+/*
+Map applies a given function to each element of a mat object. The given
+function must take a pointer to an element, and return nothing. For eaxmple,
+lets say that we wish to take the error function of each element of a Mat. The
+following would do this:
+
+	m.Map(func(i *float64) {
+		*i = math.Erf(*i)
+	})
+*/
+func (m *Mat[T]) Map(f func(*T)) *Mat[T] {
+	m.each(func(_ int, run []T) {
+		for i := range run {
+			f(&run[i])
+		}
+	})
+	return m
+}
+
+/*
+SetCol Sets all elements in a given column to the passed value(s). Negative
+index values are allowed. For  example:
+
+	m.SetCol(-1, 2.0)
+
+sets all values of m's last column to 2.0. It is also possible to pass a slice
+of elements to this function, all the elements of the chosen column will be
+set to the corresponding values in the slice. For example:
+
+	m := matrix.Newf64(2, 2).SetCol(0, []float64{1.0, 2.0})
+
+sets to values in the first column of m to 1.0 and 2.0 respectively. Note that
+in this case, the length of the passed slice must match exactly the number of
+elements in m's column, i.e. the number of rows of m.
+*/
+func (m *Mat[T]) SetCol(col int, floatOrSlice interface{}) *Mat[T] {
+	if err := m.SetColE(col, floatOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetColE is the same as SetCol, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) SetColE(col int, floatOrSlice interface{}) error {
+	if (col >= m.c) || (col < -m.c) {
+		return &IndexOutOfRangeError{Op: "SetCol()", Axis: 1, Index: col, Bound: m.c}
+	}
+	if col < 0 {
+		col += m.c
+	}
+	if val, ok := scalar[T](floatOrSlice); ok {
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.stride+col] = val
+		}
+		return nil
+	}
+	switch val := floatOrSlice.(type) {
+	case []T:
+		if len(val) != m.r {
+			return &ShapeMismatchError{Op: "SetCol()", Want: []int{m.r}, Got: []int{len(val)}}
+		}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.stride+col] = val[r]
+		}
+	default:
+		return &UnsupportedTypeError{Op: "SetCol()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+/*
+SetRow Sets all elements in a given column to the passed value(s). Negative
+index values are allowed. For  example:
+
+	m.SetRow(-1, 2.0)
+
+sets all values of m's last row to 2.0. It is also possible to pass a slice
+of elements to this function, all the elements of the chosen row will be
+set to the corresponding values in the slice. For example:
+
+	m := matrix.Newf64(2, 2).SetRow(0, []float64{1.0, 2.0})
+
+sets to values in the first row of m to 1.0 and 2.0 respectively. Note that
+in this case, the length of the passed slice must match exactly the number of
+elements in m's row, i.e. the number of cols of m.
+*/
+func (m *Mat[T]) SetRow(row int, floatOrSlice interface{}) *Mat[T] {
+	if err := m.SetRowE(row, floatOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetRowE is the same as SetRow, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) SetRowE(row int, floatOrSlice interface{}) error {
+	if (row >= m.r) || (row < -m.r) {
+		return &IndexOutOfRangeError{Op: "SetRow()", Axis: 0, Index: row, Bound: m.r}
+	}
+	if row < 0 {
+		row += m.r
+	}
+	if val, ok := scalar[T](floatOrSlice); ok {
+		for r := 0; r < m.c; r++ {
+			m.vals[row*m.stride+r] = val
+		}
+		return nil
+	}
+	switch val := floatOrSlice.(type) {
+	case []T:
+		if len(val) != m.c {
+			return &ShapeMismatchError{Op: "SetRow()", Want: []int{m.c}, Got: []int{len(val)}}
+		}
+		for r := 0; r < m.c; r++ {
+			m.vals[row*m.stride+r] = val[r]
+		}
+	default:
+		return &UnsupportedTypeError{Op: "SetRow()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+/*
+Col returns a new mat object whose values are equal to a column of the original
+mat object. The number of Rows of the returned mat object is equal to the
+number of rows of the original mat, and the number of columns is equal to 1.
+
+This function supports negative indexing. For example,
+
+	v := m.Col(-1)
+
+returns the last column of m.
+*/
+func (m *Mat[T]) Col(x int) *Mat[T] {
+	n, err := m.ColE(x)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+ColE is the same as Col, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) ColE(x int) (*Mat[T], error) {
+	if (x >= m.c) || (x < -m.c) {
+		return nil, &IndexOutOfRangeError{Op: "Col()", Axis: 1, Index: x, Bound: m.c}
+	}
+	v := New[T](m.r, 1)
+	if x >= 0 {
+		for r := 0; r < m.r; r++ {
+			v.vals[r] = m.vals[r*m.stride+x]
+		}
+	} else {
+		for r := 0; r < m.r; r++ {
+			v.vals[r] = m.vals[r*m.stride+(m.c+x)]
+		}
+	}
+	return v, nil
+}
+
+/*
+Row returns a new mat object whose values are equal to a row of the original
+mat object. The number of Rows of the returned mat object is equal to 1, and
+the number of columns is equal to the number of columns of the original mat.
+
+This function supports negative indexing. For example,
+
+	v := m.Row(-1)
+
+returns the last row of m.
+*/
+func (m *Mat[T]) Row(x int) *Mat[T] {
+	n, err := m.RowE(x)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+RowE is the same as Row, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) RowE(x int) (*Mat[T], error) {
+	if (x >= m.r) || (x < -m.r) {
+		return nil, &IndexOutOfRangeError{Op: "Row()", Axis: 0, Index: x, Bound: m.r}
+	}
+	v := New[T](1, m.c)
+	if x >= 0 {
+		for r := 0; r < m.c; r++ {
+			v.vals[r] = m.vals[x*m.stride+r]
+		}
+	} else {
+		for r := 0; r < m.c; r++ {
+			v.vals[r] = m.vals[(m.r+x)*m.stride+r]
+		}
+	}
+	return v, nil
+}
+
+/*
+Min returns the index and the value of the smallest element in a Mat. This
+method can be called in one of two ways:
+
+	idx, val := m.Min()
+
+will return the index, and value of the smallest element in m. We can also
+specify the exact row and column for which we want the minimum index and
+values:
+
+	idx, val := m.Min(0, 3) // Get the min index and value of the 4th row
+	idx, val := m.Min(1, 2) // Get the min index and value of the 3rd column
+
+Note that negative index values are not supported at this time. Also note that
+in the case where multiple values are the maximum, the index of the first
+encountered value is returned.
+*/
+func (m *Mat[T]) Min(args ...int) (int, T) {
+	index, minVal, err := m.MinE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return index, minVal
+}
+
+/*
+MinE is the same as Min, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) MinE(args ...int) (index int, minVal T, err error) {
+	switch len(args) {
+	case 0:
+		if m.r*m.c == 0 {
+			return 0, 0, &ArgumentError{Op: "Min()", Msg: "the matrix is empty"}
+		}
+		index = 0
+		minVal = m.vals[0]
+		m.each(func(off int, run []T) {
+			for i, v := range run {
+				if v < minVal {
+					minVal = v
+					index = off + i
+				}
+			}
+		})
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: "Min()", Axis: 0, Index: slice, Bound: m.r}
+			}
+			if m.c == 0 {
+				return 0, 0, &ArgumentError{Op: "Min()", Msg: "the row is empty"}
+			}
+			index = 0
+			minVal = m.vals[slice*m.stride]
+			for i := 1; i < m.c; i++ {
+				if m.vals[slice*m.stride+i] < minVal {
+					minVal = m.vals[slice*m.stride+i]
+					index = i
+				}
+			}
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: "Min()", Axis: 1, Index: slice, Bound: m.c}
+			}
+			if m.r == 0 {
+				return 0, 0, &ArgumentError{Op: "Min()", Msg: "the column is empty"}
+			}
+			index = 0
+			minVal = m.vals[slice]
+			for i := 1; i < m.r; i++ {
+				if m.vals[i*m.stride+slice] < minVal {
+					minVal = m.vals[i*m.stride+slice]
+					index = i
+				}
+			}
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, 0, &ArgumentError{Op: "Min()", Msg: fmt.Sprintf(s, axis)}
+		} // Switch on axis
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, 0, &ArgumentError{Op: "Min()", Msg: fmt.Sprintf(s, len(args))}
+	} // switch on len(args)
+	return index, minVal, nil
+}
+
+/*
+Max returns the index and the value of the biggest element in a Mat. This
+method can be called in one of two ways:
+
+	idx, val := m.Max()
+
+will return the index, and value of the biggest element in m. We can also
+specify the exact row and column for which we want the minimum index and
+values:
+
+	idx, val := m.Max(0, 3) // Get the max index and value of the 4th row
+	idx, val := m.Max(1, 2) // Get the max index and value of the 3rd column
+
+Note that negative index values are not supported at this time. Also note that
+in the case where multiple values are the maximum, the index of the first
+encountered value is returned.
+*/
+func (m *Mat[T]) Max(args ...int) (int, T) {
+	index, maxVal, err := m.MaxE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return index, maxVal
+}
+
+/*
+MaxE is the same as Max, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) MaxE(args ...int) (index int, maxVal T, err error) {
+	switch len(args) {
+	case 0:
+		if m.r*m.c == 0 {
+			return 0, 0, &ArgumentError{Op: "Max()", Msg: "the matrix is empty"}
+		}
+		index = 0
+		maxVal = m.vals[0]
+		m.each(func(off int, run []T) {
+			for i, v := range run {
+				if v > maxVal {
+					maxVal = v
+					index = off + i
+				}
+			}
+		})
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: "Max()", Axis: 0, Index: slice, Bound: m.r}
+			}
+			if m.c == 0 {
+				return 0, 0, &ArgumentError{Op: "Max()", Msg: "the row is empty"}
+			}
+			index = 0
+			maxVal = m.vals[slice*m.stride]
+			for i := 1; i < m.c; i++ {
+				if m.vals[slice*m.stride+i] > maxVal {
+					maxVal = m.vals[slice*m.stride+i]
+					index = i
+				}
+			}
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, 0, &IndexOutOfRangeError{Op: "Max()", Axis: 1, Index: slice, Bound: m.c}
+			}
+			if m.r == 0 {
+				return 0, 0, &ArgumentError{Op: "Max()", Msg: "the column is empty"}
+			}
+			index = 0
+			maxVal = m.vals[slice]
+			for i := 1; i < m.r; i++ {
+				if m.vals[i*m.stride+slice] > maxVal {
+					maxVal = m.vals[i*m.stride+slice]
+					index = i
+				}
+			}
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, 0, &ArgumentError{Op: "Max()", Msg: fmt.Sprintf(s, axis)}
+		} // Switch on axis
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, 0, &ArgumentError{Op: "Max()", Msg: fmt.Sprintf(s, len(args))}
+	} // switch on len(args)
+	return index, maxVal, nil
+}
+
+/*
+Equals checks to see if two mat objects are equal. That mean that the two mats
+have the same number of rows, same number of columns, and have the same value
+in each entry at a given index.
+*/
+func (m *Mat[T]) Equals(n *Mat[T]) bool {
+	if m.r != n.r {
+		return false
+	}
+	if m.c != n.c {
+		return false
+	}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if m.vals[i*m.stride+j] != n.vals[i*n.stride+j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+Copy returns a duplicate of a mat object. The returned copy is "deep", meaning
+that the object can be manipulated without effecting the original mat object.
+The copy of a view does not share its values with the parent of the view.
+*/
+func (m *Mat[T]) Copy() *Mat[T] {
+	n := New[T](m.r, m.c)
+	m.each(func(off int, run []T) {
+		copy(n.vals[off:], run)
+	})
+	return n
+}
+
+/*
+T returns the transpose of the original matrix. The transpose of a mat object
+is defined in the usual manner, where every value at row x, and column y is
+placed at row y, and column x. The number of rows and column of the transposed
+mat are equal to the number of columns and rows of the original matrix,
+respectively. This method creates a new mat object, and the original is
+left intact.
+*/
+func (m *Mat[T]) T() *Mat[T] {
+	if m.isRowVector() || m.isColVector() {
+		n := m.Copy()
+		n.r, n.c = n.c, n.r
+		n.stride = n.c
+		return n
+	}
+	n := New[T](m.c, m.r)
+	idx := 0
+	for i := 0; i < m.c; i++ {
+		for j := 0; j < m.r; j++ {
+			n.vals[idx] = m.vals[j*m.stride+i]
+			idx++
+		}
+	}
+	return n
+}
+
+func (m *Mat[T]) isRowVector() bool {
+	if m.r == 1 {
+		return true
+	}
+	return false
+}
+
+func (m *Mat[T]) isColVector() bool {
+	if m.c == 1 {
+		return true
+	}
+	return false
+}
+
+// isSymmetric reports whether m is square and equal to its transpose, up to
+// a small multiple of the machine epsilon of T.
+func (m *Mat[T]) isSymmetric() bool {
+	if m.r != m.c {
+		return false
+	}
+	eps := epsilon[T]()
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < i; j++ {
+			a, b := float64(m.vals[i*m.stride+j]), float64(m.vals[j*m.stride+i])
+			if math.Abs(a-b) > 8*eps*(math.Abs(a)+math.Abs(b)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+All checks if a supplied function is true for all elements of a mat object.
+For instance, consider
+
+	m.All(matrix.Positive[float64])
+
+will return true if and only if all elements in m are positive. See also
+Negative, Zero, NaN and Inf.
+*/
+func (m *Mat[T]) All(f func(*T) bool) bool {
+	for i := 0; i < m.r; i++ {
+		row := m.row(i)
+		for j := range row {
+			if !f(&row[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+/*
+Any checks if a supplied function is true for one elements of a mat object.
+For instance,
+
+	m.Any(matrix.Positive[float64])
+
+would be true if at least one element of the mat object is positive.
+*/
+func (m *Mat[T]) Any(f func(*T) bool) bool {
+	for i := 0; i < m.r; i++ {
+		row := m.row(i)
+		for j := range row {
+			if f(&row[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+Mul carries the multiplication operation between each element of the receiver
+and an object passed to it. Based on the type of the passed object, the results
+of this method changes:
+
+If the passed object is a float64 or float32, then each element is multiplied by it:
+
+	m := matrix.Newf64(2, 3).SetAll(5.0)
+	m.Mul(2.0)
+
+This will result in all values of m being 10.0.
+The passed Object can also be a Mat, in which case each element of the receiver
+are multiplied by the corresponding element of the passed Mat. The passed Mat
+must have the same shape as the receiver, or be broadcast to it as described in
+Add.
+
+	m := matrix.Newf64(2, 3).SetAll(10.0)
+	n := m.Copy()
+	m.Mul(n)
+
+This will result in each element of m being 100.0.
+
+Note: For the matrix cross product see the Dot() method.
+*/
+func (m *Mat[T]) Mul(floatOrMat interface{}) *Mat[T] {
+	if err := m.MulE(floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+MulE is the same as Mul, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) MulE(floatOrMat interface{}) error {
+	if x, ok := scalar[T](floatOrMat); ok {
+		m.each(func(_ int, run []T) {
+			for i := range run {
+				run[i] *= x
+			}
+		})
+		return nil
+	}
+	switch v := floatOrMat.(type) {
+	case *Mat[T]:
+		return m.broadcast("Mul()", v, vecMul[T])
+	default:
+		return &UnsupportedTypeError{Op: "Mul()", Type: reflect.TypeOf(v)}
+	}
+}
+
+/*
+Add carries the addition operation between each element of the receiver
+and an object passed to it. Based on the type of the passed object, the results
+of this method changes:
+
+If the passed object is a float64 or float32, then it is added to each element:
+
+	m := matrix.Newf64(2, 3).SetAll(5.0)
+	m.Add(2.0)
+
+This will result in all values of m being 7.0.
+The passed Object can also be a Mat, in which case each element of the element
+of the passed Mat is added to the corresponding element of the receiver.
+
+	m := matrix.Newf64(2, 3).SetAll(10.0)
+	n := m.Copy()
+	m.Add(n)
+
+This will result in each element of m being 20.0.
+
+The passed Mat can also be broadcast to the shape of the receiver, in the same
+way as NumPy does it. That is, it can be a row vector with as many columns as
+the receiver, which is added to each row, a column vector with as many rows as
+the receiver, which is added to each column, or a 1 by 1 Mat, which is added to
+each element. For example, to center the columns of m:
+
+	m.Sub(means) // means is a 1 by c row vector of the column averages
+
+Broadcasting applies in the same way to Sub, Mul and Div.
+*/
+func (m *Mat[T]) Add(floatOrMat interface{}) *Mat[T] {
+	if err := m.AddE(floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AddE is the same as Add, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) AddE(floatOrMat interface{}) error {
+	if x, ok := scalar[T](floatOrMat); ok {
+		m.each(func(_ int, run []T) {
+			for i := range run {
+				run[i] += x
+			}
+		})
+		return nil
+	}
+	switch v := floatOrMat.(type) {
+	case *Mat[T]:
+		return m.broadcast("Add()", v, vecAdd[T])
+	default:
+		return &UnsupportedTypeError{Op: "Add()", Type: reflect.TypeOf(v)}
+	}
+}
+
+/*
+Sub carries the subtraction operation between each element of the receiver
+and an object passed to it. Based on the type of the passed object, the results
+of this method changes:
+
+If the passed object is a float64 or float32, then it is subtracted from each element:
+
+	m := matrix.Newf64(2, 3).SetAll(5.0)
+	m.Sub(2.0)
+
+This will result in all values of m being 3.0.
+The passed Object can also be a Mat, in which case each element of the passed
+Mat is subtracted from the corresponding element of the receiver. The passed
+Mat must have the same shape as the receiver, or be broadcast to it as described
+in Add.
+
+	m := matrix.Newf64(2, 3).SetAll(10.0)
+	n := m.Copy()
+	m.Sub(n)
+
+This will result in each element of m being 0.0.
+*/
+func (m *Mat[T]) Sub(floatOrMat interface{}) *Mat[T] {
+	if err := m.SubE(floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SubE is the same as Sub, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) SubE(floatOrMat interface{}) error {
+	if x, ok := scalar[T](floatOrMat); ok {
+		m.each(func(_ int, run []T) {
+			for i := range run {
+				run[i] -= x
+			}
+		})
+		return nil
+	}
+	switch v := floatOrMat.(type) {
+	case *Mat[T]:
+		return m.broadcast("Sub()", v, vecSub[T])
+	default:
+		return &UnsupportedTypeError{Op: "Sub()", Type: reflect.TypeOf(v)}
+	}
+}
+
+/*
+Div carries the division operation between each element of the receiver
+and an object passed to it. Based on the type of the passed object, the results
+of this method changes:
+
+If the passed object is a float64 or float32, then each element of the receiver is devided
+by it:
+
+	m := matrix.Newf64(2, 3).SetAll(5.0)
+	m.Div(2.0)
+
+This will result in all values of m being 2.5. Note that the passed T
+cannot be 0.0.
+
+The passed Object can also be a Mat, in which case each element of the passed
+Mat divides the corresponding element of the receiver. The passed Mat must have
+the same shape as the receiver, or be broadcast to it as described in Add, and
+it cannot contains any elements which are 0.0.
+
+	m := matrix.Newf64(2, 3).SetAll(10.0)
+	n := m.Copy()
+	m.Div(n)
+
+This will result in each element of m being 1.0.
+*/
+func (m *Mat[T]) Div(floatOrMat interface{}) *Mat[T] {
+	if err := m.DivE(floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+DivE is the same as Div, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) DivE(floatOrMat interface{}) error {
+	if x, ok := scalar[T](floatOrMat); ok {
+		m.each(func(_ int, run []T) {
+			for i := range run {
+				run[i] /= x
+			}
+		})
+		return nil
+	}
+	switch v := floatOrMat.(type) {
+	case *Mat[T]:
+		return m.broadcast("Div()", v, vecDiv[T])
+	default:
+		return &UnsupportedTypeError{Op: "Div()", Type: reflect.TypeOf(v)}
+	}
+}
+
+/*
+Sum takes the sum of the elements of a Mat. It can be called in one of two ways:
+
+	m.Sum()
+
+This will return the sum of all elements in m. This method can also be called by
+passing 2 integers: 0 or 1 for row or column, and another int specifying the
+row or column. For example:
+
+	m.Sum(0, 2) // Returns the sum of the 3rd row
+	m.Sum(1, 0) // Returns the sum of the first column.
+
+Note that second passed integer cannot be less than 0, or greater that the
+length of the matrix in that dimension.
+*/
+func (m *Mat[T]) Sum(args ...int) T {
+	x, err := m.SumE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+SumE is the same as Sum, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) SumE(args ...int) (T, error) {
+	var sum T
+	switch len(args) {
+	case 0:
+		m.each(func(_ int, run []T) {
+			for _, v := range run {
+				sum += v
+			}
+		})
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Sum()", Axis: 0, Index: slice, Bound: m.r}
+			}
+			for i := 0; i < m.c; i++ {
+				sum += m.vals[slice*m.stride+i]
+			}
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Sum()", Axis: 1, Index: slice, Bound: m.c}
+			}
+			for i := 0; i < m.r; i++ {
+				sum += m.vals[i*m.stride+slice]
+			}
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, &ArgumentError{Op: "Sum()", Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, &ArgumentError{Op: "Sum()", Msg: fmt.Sprintf(s, len(args))}
+	}
+	return sum, nil
+}
+
+/*
+Avg takes the average of the elements of a Mat. It can be called in one of two ways:
+
+	m.Avg()
+
+This will return the average of all elements in m. This method can also be
+called by passing 2 integers: 0 or 1 for row or column, and another int
+specifying the row or column. For example:
+
+	m.Avg(0, 2) // Returns the average of the 3rd row
+	m.Avg(1, 0) // Returns the average of the first column.
+
+Note that second passed integer cannot be less than 0, or greater that the
+length of the matrix in that dimension.
+*/
+func (m *Mat[T]) Avg(args ...int) T {
+	x, err := m.AvgE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+AvgE is the same as Avg, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) AvgE(args ...int) (T, error) {
+	var sum T
+	switch len(args) {
+	case 0:
+		m.each(func(_ int, run []T) {
+			for _, v := range run {
+				sum += v
+			}
+		})
+		sum /= T(m.r * m.c)
+	case 2:
+		axis, slice := args[0], args[1]
+		if axis == 0 {
+			if (slice >= m.r) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Avg()", Axis: 0, Index: slice, Bound: m.r}
+			}
+			for i := 0; i < m.c; i++ {
+				sum += m.vals[slice*m.stride+i]
+			}
+			sum /= T(m.c)
+		} else if axis == 1 {
+			if (slice >= m.c) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Avg()", Axis: 1, Index: slice, Bound: m.c}
+			}
+			for i := 0; i < m.r; i++ {
+				sum += m.vals[i*m.stride+slice]
+			}
+			sum /= T(m.r)
+		} else {
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, &ArgumentError{Op: "Avg()", Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, &ArgumentError{Op: "Avg()", Msg: fmt.Sprintf(s, len(args))}
+	}
+	return sum, nil
+}
+
+/*
+Prd takes the product of the elements of a Mat. It can be called in one of two
+ways:
+
+	m.Prd()
+
+This will return the product of all elements in m. This method can also be
+called by passing 2 integers: 0 or 1 for row or column, and another int
+specifying the row or column. For example:
+
+	m.Prd(0, 2) // Returns the product of the 3rd row
+	m.Prd(1, 0) // Returns the product of the first column.
+
+Note that second passed integer cannot be less than 0, or greater that the
+length of the matrix in that dimension.
+*/
+func (m *Mat[T]) Prd(args ...int) T {
+	x, err := m.PrdE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+PrdE is the same as Prd, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) PrdE(args ...int) (T, error) {
+	prd := T(1.0)
+	switch len(args) {
+	case 0:
+		m.each(func(_ int, run []T) {
+			for _, v := range run {
+				prd *= v
+			}
+		})
+	case 2:
+		axis, slice := args[0], args[1]
+		if axis == 0 {
+			if (slice >= m.r) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Prd()", Axis: 0, Index: slice, Bound: m.r}
+			}
+			for i := 0; i < m.c; i++ {
+				prd *= m.vals[slice*m.stride+i]
+			}
+		} else if axis == 1 {
+			if (slice >= m.c) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: "Prd()", Axis: 1, Index: slice, Bound: m.c}
+			}
+			for i := 0; i < m.r; i++ {
+				prd *= m.vals[i*m.stride+slice]
+			}
+		} else {
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, &ArgumentError{Op: "Prd()", Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, &ArgumentError{Op: "Prd()", Msg: fmt.Sprintf(s, len(args))}
+	}
+	return prd, nil
+}
+
+/*
+Var takes the population variance of the elements of a Mat, that is the mean
+of the squared deviations from their average. It can be called in the same
+ways as Avg:
+
+	m.Var()     // Returns the variance of all elements in m.
+	m.Var(0, 2) // Returns the variance of the 3rd row.
+	m.Var(1, 0) // Returns the variance of the first column.
+
+The variance is computed in a single pass with Welford's algorithm, which
+does not lose precision when the values are large compared to their spread.
+To take the sample variance instead, see VarDdof.
+*/
+func (m *Mat[T]) Var(args ...int) T {
+	x, err := m.VarE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+VarE is the same as Var, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) VarE(args ...int) (T, error) {
+	return m.variance("Var()", 0, args)
+}
+
+/*
+VarDdof is the same as Var, except that the sum of the squared deviations is
+divided by n - ddof, where n is the number of values, instead of by n. A
+ddof ("delta degrees of freedom") of 0 gives the population variance, while
+1 gives the unbiased sample variance:
+
+	m.VarDdof(1)       // The sample variance of all elements in m.
+	m.VarDdof(1, 1, 0) // The sample variance of the first column.
+
+ddof cannot be negative, and must be less than the number of values.
+*/
+func (m *Mat[T]) VarDdof(ddof int, args ...int) T {
+	x, err := m.VarDdofE(ddof, args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+VarDdofE is the same as VarDdof, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) VarDdofE(ddof int, args ...int) (T, error) {
+	return m.variance("VarDdof()", ddof, args)
+}
+
+/*
+Std takes the population standard deviation of the elements of a Mat, which
+is the square root of Var. It can be called in one of two ways:
+
+	m.Std()
+
+This will return the std. div. of all elements in m. This method can also be
+called by passing 2 integers: 0 or 1 for row or column, and another int
+specifying the row or column. For example:
+
+	m.Std(0, 2) // Returns the standard deviation of the 3rd row
+	m.Std(1, 0) // Returns the standard deviation of the first column.
+
+Note that second passed integer cannot be less than 0, or greater that the
+length of the matrix in that dimension. To take the sample standard
+deviation instead, see StdDdof.
+*/
+func (m *Mat[T]) Std(args ...int) T {
+	x, err := m.StdE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+StdE is the same as Std, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) StdE(args ...int) (T, error) {
+	v, err := m.variance("Std()", 0, args)
+	return T(math.Sqrt(float64(v))), err
+}
+
+/*
+StdDdof is the square root of VarDdof, and takes ddof in the same way:
+
+	m.StdDdof(1, 0, 2) // The sample standard deviation of the 3rd row.
+*/
+func (m *Mat[T]) StdDdof(ddof int, args ...int) T {
+	x, err := m.StdDdofE(ddof, args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+StdDdofE is the same as StdDdof, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) StdDdofE(ddof int, args ...int) (T, error) {
+	v, err := m.variance("StdDdof()", ddof, args)
+	return T(math.Sqrt(float64(v))), err
+}
+
+// welford accumulates the mean and the sum of squared deviations of a
+// sequence of values in a single, numerically stable pass.
+type welford struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (w *welford) push(x float64) {
+	w.n++
+	d := x - w.mean
+	w.mean += d / float64(w.n)
+	w.m2 += d * (x - w.mean)
+}
+
+// variance returns the sum of squared deviations of the pushed values
+// divided by n - ddof, or an *ArgumentError if ddof is not in [0, n).
+func (w *welford) variance(op string, ddof int) (float64, error) {
+	if ddof < 0 || ddof >= w.n {
+		s := "ddof must be in [0, %d), but %d was received"
+		return 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, w.n, ddof)}
+	}
+	return w.m2 / float64(w.n-ddof), nil
+}
+
+// variance returns the variance of the values of m selected by args, as
+// described in Var, with the sum of squared deviations divided by n - ddof.
+func (m *Mat[T]) variance(op string, ddof int, args []int) (T, error) {
+	var w welford
+	switch len(args) {
+	case 0:
+		m.each(func(_ int, run []T) {
+			for _, v := range run {
+				w.push(float64(v))
+			}
+		})
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: op, Axis: 0, Index: slice, Bound: m.r}
+			}
+			for _, v := range m.row(slice) {
+				w.push(float64(v))
+			}
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: op, Axis: 1, Index: slice, Bound: m.c}
+			}
+			for i := 0; i < m.r; i++ {
+				w.push(float64(m.vals[i*m.stride+slice]))
+			}
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(args))}
+	}
+	v, err := w.variance(op, ddof)
+	return T(v), err
+}
+
+/*
+Dot is the matrix multiplication of two mat objects. Consider the following two
+mats:
+
+	m := matrix.Newf64(5, 6)
+	n := matrix.Newf64(6, 10)
+
+then
+
+	o := m.Dot(n)
+
+is a 5 by 10 mat whose element at row i and column j is given by:
+
+	Sum(m.Row(i).Mul(n.col(j))
+
+The product is computed in cache sized tiles, and large products are split
+across multiple goroutines, see SetDotThreshold. To multiply into an existing
+matrix without allocating, see Gemm.
+*/
+func (m *Mat[T]) Dot(n *Mat[T]) *Mat[T] {
+	n, err := m.DotE(n)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+DotE is the same as Dot, except that it returns an error instead of exiting
+the program.
+*/
+func (m *Mat[T]) DotE(n *Mat[T]) (*Mat[T], error) {
+	if m.c != n.r {
+		return nil, &ShapeMismatchError{
+			Op:   "Dot()",
+			Want: []int{m.c, n.c},
+			Got:  []int{n.r, n.c},
+		}
+	}
+	o := New[T](m.r, n.c)
+	gemm(NoTrans, NoTrans, 1, m, n, o)
+	return o, nil
+}
+
+/*
+String returns the string representation of a mat. This is done by putting
+every row into a line, and separating the entries of that row by a space. note
+that the last line does not contain a newline.
+*/
+func (m *Mat[T]) String() string {
+	var str string
+	str += "["
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if j == 0 {
+				str += "["
+			}
+			str += strconv.FormatFloat(float64(m.vals[i*m.stride+j]), 'f', 14, bitSize[T]())
+			if j+1 != m.c {
+				str += ",\t"
+			}
+		}
+		if i+1 <= m.r {
+			str += "]\n "
+		}
+	}
+	str = str[:len(str)-2] // take out the last newline.
+	str += "]\n"
+	return str
+}
+
+/*
+AppendCol appends a column to the right side of a Mat.
+*/
+func (m *Mat[T]) AppendCol(v []T) *Mat[T] {
+	if err := m.AppendColE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendColE is the same as AppendCol, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) AppendColE(v []T) error {
+	if m.r != len(v) {
+		return &ShapeMismatchError{Op: "AppendCol()", Want: []int{m.r}, Got: []int{len(v)}}
+	}
+	if !m.contiguous() {
+		m.Materialize()
+	}
+	// TODO: redo this by hand, instead of taking this shortcut... or check if
+	// this is a huge bottleneck
+	q := m.ToSlice2D()
+	for i := range q {
+		q[i] = append(q[i], v[i])
+	}
+	m.c++
+	m.stride = m.c
+	m.vals = append(m.vals, v...)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			m.vals[i*m.c+j] = q[i][j]
+		}
+	}
+	return nil
+}
+
+/*
+AppendRow appends a row to the bottom of a Mat.
+*/
+func (m *Mat[T]) AppendRow(v []T) *Mat[T] {
+	if err := m.AppendRowE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendRowE is the same as AppendRow, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) AppendRowE(v []T) error {
+	if m.c != len(v) {
+		return &ShapeMismatchError{Op: "AppendRow()", Want: []int{m.c}, Got: []int{len(v)}}
+	}
+	if !m.contiguous() {
+		m.Materialize()
+	}
+	if cap(m.vals) < (len(m.vals) + len(v)) {
+		newVals := make([]T, len(m.vals)+len(v), len(m.vals)+len(v)*2)
+		lastElem := len(m.vals)
+		for i := range m.vals {
+			newVals[i] = m.vals[i]
+		}
+		for i := range v {
+			newVals[lastElem+i] = v[i]
+		}
+		m.vals = newVals
+	} else {
+		m.vals = append(m.vals, v...)
+	}
+	m.r++
+	return nil
+}
+
+/*
+Concat merges a passed mat to the right side of the receiver. The passed mat
+must therefore have the same number of rows as the receiver.
+For example:
+
+	m := matrix.Newf64(1, 2).SetAll(2.0) // [[2.0, 2.0]]
+	n := matrix.Newf64(1, 3).SetAll(3.0) // [[3.0, 3.0, 3.0]]
+	m.Concat(n)
+	fmt.Println(m) // [[2.0, 2.0, 3.0, 3.0, 3.0]]
+
+Note that in the current implementation this is a somewhat expensive function.
+*/
+func (m *Mat[T]) Concat(n *Mat[T]) *Mat[T] {
+	if err := m.ConcatE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ConcatE is the same as Concat, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) ConcatE(n *Mat[T]) error {
+	if m.r != n.r {
+		return &ShapeMismatchError{Op: "Concat()", Want: []int{m.r, n.c}, Got: []int{n.r, n.c}}
+	}
+	if !m.contiguous() {
+		m.Materialize()
+	}
+	q := m.ToSlice2D()
+	t := n.ToSlice1D()
+	r := n.ToSlice2D()
+	m.vals = append(m.vals, t...)
+	for i := range q {
+		q[i] = append(q[i], r[i]...)
+	}
+	m.c += n.c
+	m.stride = m.c
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			m.vals[i*m.c+j] = q[i][j]
+		}
+	}
+	return nil
+}
+
+/*
+Append merges a passed mat to the botton of the receiver. The passed mat
+must therefore have the same number of columns as the receiver.
+For example:
+
+	m := matrix.Newf64(1, 2).SetAll(2.0) // [[2.0, 2.0]]
+	n := matrix.Newf64(2, 2).SetAll(3.0) // [[3.0, 3.0], [3.0, 3.0]]
+	m.Append(n)
+	fmt.Println(m) // [[2.0, 2.0], [3.0, 3.0], [3.0, 3.0]]
+
+Note that in the current implementation this is a somewhat expensive function.
+*/
+func (m *Mat[T]) Append(n *Mat[T]) *Mat[T] {
+	if err := m.AppendE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendE is the same as Append, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) AppendE(n *Mat[T]) error {
+	if m.c != n.c {
+		return &ShapeMismatchError{Op: "Append()", Want: []int{n.r, m.c}, Got: []int{n.r, n.c}}
+	}
+	if !m.contiguous() {
+		m.Materialize()
+	}
+	m.vals = append(m.vals, n.ToSlice1D()...)
+	m.r += n.r
+	return nil
+}
+
+// broadcast applies vec, which is one of vecAdd, vecSub, vecMul and vecDiv,
+// to m and n. If n has the same shape as m, the values are paired up one to
+// one. Otherwise, n must be a 1 by 1 matrix, a row vector with as many
+// columns as m, or a column vector with as many rows as m, which is
+// repeated to the shape of m.
+func (m *Mat[T]) broadcast(op string, n *Mat[T], vec func(a, b []T)) error {
+	switch {
+	case n.r == m.r && n.c == m.c:
+		eachPair(m, n, vec)
+	case n.isRowVector() && n.isColVector():
+		buf := make([]T, m.c)
+		for j := range buf {
+			buf[j] = n.vals[0]
+		}
+		for i := 0; i < m.r; i++ {
+			vec(m.row(i), buf)
+		}
+	case n.isRowVector() && n.c == m.c:
+		// n is copied in case that it is a view into m.
+		row := n.ToSlice1D()
+		for i := 0; i < m.r; i++ {
+			vec(m.row(i), row)
+		}
+	case n.isColVector() && n.r == m.r:
+		col := n.ToSlice1D()
+		buf := make([]T, m.c)
+		for i := 0; i < m.r; i++ {
+			for j := range buf {
+				buf[j] = col[i]
+			}
+			vec(m.row(i), buf)
+		}
+	default:
+		return &ShapeMismatchError{Op: op, Want: []int{m.r, m.c}, Got: []int{n.r, n.c}}
+	}
+	return nil
+}
+
+// vecAdd, vecSub, vecMul and vecDiv carry out the element-wise operations of
+// a and b in place, storing the results in a. They use the vecf64 and vecf32
+// packages when T is exactly float64 or float32.
+func vecAdd[T Float](a, b []T) {
+	switch x := interface{}(a).(type) {
+	case []float64:
+		vecf64.Add(x, interface{}(b).([]float64))
+	case []float32:
+		vecf32.Add(x, interface{}(b).([]float32))
+	default:
+		for i := range a {
+			a[i] += b[i]
+		}
+	}
+}
+
+func vecSub[T Float](a, b []T) {
+	switch x := interface{}(a).(type) {
+	case []float64:
+		vecf64.Sub(x, interface{}(b).([]float64))
+	case []float32:
+		vecf32.Sub(x, interface{}(b).([]float32))
+	default:
+		for i := range a {
+			a[i] -= b[i]
+		}
+	}
+}
+
+func vecMul[T Float](a, b []T) {
+	switch x := interface{}(a).(type) {
+	case []float64:
+		vecf64.Mul(x, interface{}(b).([]float64))
+	case []float32:
+		vecf32.Mul(x, interface{}(b).([]float32))
+	default:
+		for i := range a {
+			a[i] *= b[i]
+		}
+	}
+}
+
+func vecDiv[T Float](a, b []T) {
+	switch x := interface{}(a).(type) {
+	case []float64:
+		vecf64.Div(x, interface{}(b).([]float64))
+	case []float32:
+		vecf32.Div(x, interface{}(b).([]float32))
+	default:
+		for i := range a {
+			a[i] /= b[i]
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type celsius float64
+
+func TestMatNamedType(t *testing.T) {
+	t.Helper()
+	m := MatFromData[celsius]([]celsius{1, 2, 3, 4, 5, 6}, 2, 3)
+	n := m.Copy()
+	m.Add(n).Mul(celsius(2))
+	assert.Equal(t, celsius(4), m.Get(0, 0), "should be equal")
+	assert.Equal(t, celsius(84), m.Sum(), "should be equal")
+	o := m.Dot(n.T())
+	r, c := o.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+}
+
+func TestI(t *testing.T) {
+	t.Helper()
+	m := I[float64](4)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if i == j {
+				assert.Equal(t, 1.0, m.Get(i, j), "should be one")
+			} else {
+				assert.Equal(t, 0.0, m.Get(i, j), "should be zero")
+			}
+		}
+	}
+	n := RandMatf64(4, 4)
+	assert.True(t, n.Equals(n.Dot(If64(4))), "A times I should equal A")
+	assert.True(t, If32(3).Equals(I[float32](3)), "should be equal")
+}
+
+func TestAppend(t *testing.T) {
+	t.Helper()
+	m := Newf64(1, 2).SetAll(2.0)
+	n := Newf64(2, 2).SetAll(3.0)
+	m.Append(n)
+	r, c := m.Shape()
+	assert.Equal(t, 3, r, "should have three rows")
+	assert.Equal(t, 2, c, "should have two columns")
+	assert.Equal(t, 3.0, m.Get(2, 1), "should be equal")
+}
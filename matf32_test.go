@@ -1,6 +1,7 @@
 package matrix
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 
 func TestNewf32(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 13
 	cols := 7
 	m := Newf32()
@@ -22,24 +24,29 @@ func TestNewf32(t *testing.T) {
 	assert.Equal(t, rows, m.c, "should be equal")
 	assert.NotNil(t, m.vals, "should not be nil")
 	assert.Equal(t, rows*rows, len(m.vals), "should be equal")
-	assert.Equal(t, rows*rows, cap(m.vals), "should be equal")
+	assert.Equal(t, 2*rows*rows, cap(m.vals), "should have twice the capacity")
 
 	m = Newf32(rows, cols)
 	assert.Equal(t, rows, m.r, "should be equal")
 	assert.Equal(t, cols, m.c, "should be equal")
 	assert.NotNil(t, m.vals, "should not be nil")
 	assert.Equal(t, rows*cols, len(m.vals), "should be equal")
-	assert.Equal(t, rows*cols, cap(m.vals), "should be equal")
+	assert.Equal(t, 2*rows*cols, cap(m.vals), "should have twice the capacity")
 
-	// assert.Panics(t, func() { Newf32(1, 2, 3, 4) }, "should panic with 3+ args")
+	assert.Panics(t, func() { Newf32(1, 2, 3, 4) }, "should panic with 3+ args")
+	_, err := Newf32E(1, 2, 3, 4)
+	assert.NotNil(t, err, "should error with 3+ args")
 }
 
 func TestMatf32FromData(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 50
 	cols := 2
 
-	// assert.Panics(t, func() { Matf32FromData(1.0) }, "should panic with wrong arg")
+	assert.Panics(t, func() { Matf32FromData(1.0) }, "should panic with wrong arg")
+	_, err := Matf32FromDataE(1.0)
+	assert.NotNil(t, err, "should error with wrong arg")
 
 	v := make([]float32, rows*cols)
 	for i := range v {
@@ -83,9 +90,13 @@ func TestMatf32FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], v[0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf32FromData(v, 12) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf32FromData(v, 11, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf32FromData(v, 1, 2, 3) }, "too many args")
+	assert.Panics(t, func() { Matf32FromData(v, 12) }, "wrong expected size")
+	assert.Panics(t, func() { Matf32FromData(v, 11, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf32FromData(v, 1, 2, 3) }, "too many args")
+	_, err = Matf32FromDataE(v, 12)
+	assert.NotNil(t, err, "wrong expected size")
+	_, err = Matf32FromDataE(v, 1, 2, 3)
+	assert.NotNil(t, err, "too many args")
 
 	s := make([][]float32, rows)
 	for i := range s {
@@ -147,13 +158,36 @@ func TestMatf32FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], s[0][0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf32FromData(s, 15) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf32FromData(s, 1, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf32FromData(s, 12, 12, 4) }, "too many args")
+	assert.Panics(t, func() { Matf32FromData(s, 15) }, "wrong expected size")
+	assert.Panics(t, func() { Matf32FromData(s, 1, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf32FromData(s, 12, 12, 4) }, "too many args")
+	_, err = Matf32FromDataE(s, 15)
+	assert.NotNil(t, err, "wrong expected size")
+	_, err = Matf32FromDataE(s, 12, 12, 4)
+	assert.NotNil(t, err, "too many args")
+}
+
+func TestMatf32FromCSV(t *testing.T) {
+	t.Helper()
+	filename := "test_f32.csv"
+	str := "1.0,1.0,2.0,3.0\n5.0,8.0,13.0,21.0\n34.0,55.0,89.0,144.0"
+	if err := os.WriteFile(filename, []byte(str), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	m := Matf32FromCSV(filename)
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 4, m.c, "should be equal")
+	assert.Equal(t, float32(1.0), m.vals[0], "should be equal")
+	assert.Equal(t, float32(1.0), m.vals[1], "should be equal")
+	for i := 2; i < m.r*m.c; i++ {
+		assert.Equal(t, (m.vals[i-1] + m.vals[i-2]), m.vals[i], "should be equal")
+	}
 }
 
 func TestRandf32(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 31
 	cols := 42
 
@@ -176,12 +210,17 @@ func TestRandf32(t *testing.T) {
 		}
 	}
 
-	// assert.Panics(t, func() { RandMatf32(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
-	// assert.Panics(t, func() { RandMatf32(rows, cols, 12.0, 2.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf32(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf32(rows, cols, 12.0, 2.0) }, "should panic")
+	_, err := RandMatf32E(rows, cols, 12.0, 2.0, 13.0)
+	assert.NotNil(t, err, "should error")
+	_, err = RandMatf32E(rows, cols, 12.0, 2.0)
+	assert.NotNil(t, err, "should error")
 }
 
 func TestReshapef32(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows, cols := 10, 12
 	s := make([]float32, 120)
 	for i := 0; i < len(s); i++ {
@@ -194,7 +233,8 @@ func TestReshapef32(t *testing.T) {
 		assert.Equal(t, s[i], m.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
+	assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
+	assert.NotNil(t, m.ReshapeE(rows, rows), "should error")
 }
 
 func TestShapef32(t *testing.T) {
@@ -262,6 +302,28 @@ func TestToSlice2Df32(t *testing.T) {
 	assert.NotEqual(t, m.vals[0], u[0][0], "changing mat should not effect data")
 }
 
+func TestToCSVf32(t *testing.T) {
+	t.Helper()
+	m := Newf32(23, 17)
+	for i := range m.vals {
+		m.vals[i] = float32(i) / 7
+	}
+	filename := "tocsv_test_f32.csv"
+	m.ToCSV(filename)
+	defer os.Remove(filename)
+	n := Matf32FromCSV(filename)
+	if !n.Equals(m) {
+		t.Errorf("m and n are not equal")
+	}
+}
+
+func TestStringf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([]float32{1, 2, 3, 4}, 2, 2)
+	assert.Equal(t, "[[1.00000000000000,\t2.00000000000000]\n [3.00000000000000,\t4.00000000000000]]\n",
+		m.String(), "should be equal")
+}
+
 func TestGetf32(t *testing.T) {
 	t.Helper()
 	rows := 17
@@ -328,6 +390,7 @@ func TestSetf32(t *testing.T) {
 
 func TestSetColf32(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	m := Newf32(3, 4)
 	m.SetCol(-1, 3.0)
 	n := m.Col(-1)
@@ -350,16 +413,20 @@ func TestSetColf32(t *testing.T) {
 		assert.Equal(t, float32(0.0), n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, []float32{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, []float32{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, []float32{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, []float32{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
+	assert.NotNil(t, m.SetColE(5, 2.0), "should error")
+	assert.NotNil(t, m.SetColE(1, []float32{0.0}), "should error")
+	assert.NotNil(t, m.SetColE(1, 1), "should error")
 }
 
 func TestSetRowf32(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	m := Newf32(3, 4)
 	m.SetRow(-1, 3.0)
 	n := m.Row(-1)
@@ -382,12 +449,15 @@ func TestSetRowf32(t *testing.T) {
 		assert.Equal(t, float32(0.0), n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, []float32{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, []float32{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, []float32{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, []float32{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
+	assert.NotNil(t, m.SetRowE(5, 2.0), "should error")
+	assert.NotNil(t, m.SetRowE(1, []float32{0.0}), "should error")
+	assert.NotNil(t, m.SetRowE(1, 1), "should error")
 }
 
 func TestColf32(t *testing.T) {
@@ -626,6 +696,8 @@ func TestAddf32(t *testing.T) {
 	for i := 0; i < rows*cols; i++ {
 		assert.Equal(t, n.vals[i]+n.vals[i], m.vals[i], "should be equal")
 	}
+	assert.NotNil(t, m.AddE(Newf32(rows, rows)), "should error")
+	assert.NotNil(t, m.AddE("a"), "should error")
 }
 
 func BenchmarkAddf32(b *testing.B) {
@@ -752,6 +824,19 @@ func TestStdf32(t *testing.T) {
 	}
 }
 
+func TestVarf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{2, 4, 4, 4},
+		{5, 5, 7, 9},
+	})
+	assert.InDelta(t, float32(4), m.Var(), 1e-5, "should be equal")
+	assert.InDelta(t, float32(2), m.Std(), 1e-5, "should be equal")
+	assert.InDelta(t, float32(2.75), m.Var(0, 1), 1e-5, "divides by the row length")
+	assert.InDelta(t, float32(2.5), m.Std(1, 3), 1e-5, "divides by the column length")
+	assert.InDelta(t, float32(12.5), m.VarDdof(1, 1, 3), 1e-5, "should be equal")
+}
+
 func TestDotf32(t *testing.T) {
 	t.Helper()
 	var (
@@ -767,6 +852,8 @@ func TestDotf32(t *testing.T) {
 		n.vals[i] = float32(i)
 	}
 	o := m.Dot(n)
+	_, err := m.DotE(m)
+	assert.NotNil(t, err, "should error")
 	assert.Equal(t, row, o.r, "should be equal")
 	assert.Equal(t, row, o.c, "should be equal")
 	p := Newf32(row, row)
@@ -795,6 +882,24 @@ func TestDotf32(t *testing.T) {
 	assert.True(t, x.Equals(z), "A times I should equal A")
 }
 
+func TestGemmf32(t *testing.T) {
+	t.Helper()
+	a := Matf32FromData([][]float32{
+		{1, 2},
+		{3, 4},
+	})
+	b := Matf32FromData([][]float32{
+		{5, 6},
+		{7, 8},
+	})
+	dst := Matf32FromData([][]float32{
+		{1, 1},
+		{1, 1},
+	})
+	dst.Gemm(Trans, NoTrans, 1, a, b, 2)
+	assert.Equal(t, []float32{28, 32, 40, 46}, dst.vals, "should be equal")
+}
+
 func BenchmarkDotf32(b *testing.B) {
 	m := Newf32(1000)
 	n := Newf32(1000)
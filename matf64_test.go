@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"log"
+	"math"
 	"os"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 func TestNewf64(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 13
 	cols := 7
 	m := Newf64()
@@ -33,15 +35,29 @@ func TestNewf64(t *testing.T) {
 	assert.Equal(t, rows*cols, len(m.vals), "should be equal")
 	assert.Equal(t, 2*rows*cols, cap(m.vals), "should have twice the capacity")
 
-	// assert.Panics(t, func() { Newf64(1, 2, 3, 4) }, "should panic with 3+ args")
+	assert.Panics(t, func() { Newf64(1, 2, 3, 4) }, "should panic with 3+ args")
+	_, err := Newf64E(1, 2, 3, 4)
+	assert.NotNil(t, err, "should error with 3+ args")
+	_, err = Newf64E(-1, 3)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newf64E(-1)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newf64E(1<<62, 4)
+	assert.IsType(t, &ArgumentError{}, err, "too large")
+	_, err = Newf64E(100000000000, 100000000000)
+	assert.IsType(t, &ArgumentError{}, err, "too large")
+	assert.Panics(t, func() { Newf64(3, -2) }, "negative dimension")
 }
 
 func TestMatf64FromData(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 50
 	cols := 2
 
-	// assert.Panics(t, func() { Matf64FromData(1.0) }, "should panic with wrong arg")
+	assert.Panics(t, func() { Matf64FromData(1.0) }, "should panic with wrong arg")
+	_, err := Matf64FromDataE(1.0)
+	assert.NotNil(t, err, "should error with wrong arg")
 
 	v := make([]float64, rows*cols)
 	for i := range v {
@@ -85,9 +101,13 @@ func TestMatf64FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], v[0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf64FromData(v, 12) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(v, 11, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(v, 1, 2, 3) }, "too many args")
+	assert.Panics(t, func() { Matf64FromData(v, 12) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(v, 11, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(v, 1, 2, 3) }, "too many args")
+	_, err = Matf64FromDataE(v, 12)
+	assert.NotNil(t, err, "wrong expected size")
+	_, err = Matf64FromDataE(v, 1, 2, 3)
+	assert.NotNil(t, err, "too many args")
 
 	s := make([][]float64, rows)
 	for i := range s {
@@ -149,19 +169,36 @@ func TestMatf64FromData(t *testing.T) {
 	m.vals[0] = 1201.0
 	assert.NotEqual(t, m.vals[0], s[0][0], "changing mat should not effect data")
 
-	// assert.Panics(t, func() { Matf64FromData(s, 15) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(s, 1, 2) }, "wrong expected size")
-	// assert.Panics(t, func() { Matf64FromData(s, 12, 12, 4) }, "too many args")
+	assert.Panics(t, func() { Matf64FromData(s, 15) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(s, 1, 2) }, "wrong expected size")
+	assert.Panics(t, func() { Matf64FromData(s, 12, 12, 4) }, "too many args")
+	_, err = Matf64FromDataE(s, 15)
+	assert.NotNil(t, err, "wrong expected size")
+	_, err = Matf64FromDataE(s, 12, 12, 4)
+	assert.NotNil(t, err, "too many args")
+
+	_, err = Matf64FromDataE([][]float64{})
+	assert.IsType(t, &ArgumentError{}, err, "empty slice")
+	_, err = Matf64FromDataE([][]float64{{1, 2}, {3}})
+	assert.IsType(t, &ArgumentError{}, err, "rows of unequal length")
+	_, err = Matf64FromDataE([]float64{1, 2}, -1, -2)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimensions")
+	_, err = Matf64FromDataE([][]float64{{1, 2}, {3, 4}}, -2)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	assert.Panics(t, func() { Matf64FromData([][]float64{{1}, {2, 3}}) }, "jagged")
 }
 
 func TestMatf64FromCSV(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 3
 	cols := 4
 
 	filename := "non-exitant-file"
 
-	// assert.Panics(t, func() { Matf64FromCSV(filename) }, "should panic")
+	assert.Panics(t, func() { Matf64FromCSV(filename) }, "should panic")
+	_, err := Matf64FromCSVE(filename)
+	assert.NotNil(t, err, "should error")
 
 	filename = "test.csv"
 	str := "1.0,1.0,2.0,3.0\n5.0,8.0,13.0,21.0\n34.0,55.0,89.0,144.0"
@@ -199,6 +236,7 @@ func TestMatf64FromCSV(t *testing.T) {
 
 func TestRandf64(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows := 31
 	cols := 42
 
@@ -221,12 +259,19 @@ func TestRandf64(t *testing.T) {
 		}
 	}
 
-	// assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
-	// assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0, 13.0) }, "should panic")
+	assert.Panics(t, func() { RandMatf64(rows, cols, 12.0, 2.0) }, "should panic")
+	_, err := RandMatf64E(rows, cols, 12.0, 2.0, 13.0)
+	assert.NotNil(t, err, "should error")
+	_, err = RandMatf64E(rows, cols, 12.0, 2.0)
+	assert.NotNil(t, err, "should error")
+	_, err = RandMatf64E(-1, 3)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
 }
 
 func TestReshapef64(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	rows, cols := 10, 12
 	s := make([]float64, 120)
 	for i := 0; i < len(s); i++ {
@@ -239,7 +284,8 @@ func TestReshapef64(t *testing.T) {
 		assert.Equal(t, s[i], m.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
+	assert.Panics(t, func() { m.Reshape(rows, rows) }, "should panic")
+	assert.NotNil(t, m.ReshapeE(rows, rows), "should error")
 }
 
 func TestShapef64(t *testing.T) {
@@ -298,6 +344,14 @@ func TestToCSVf64(t *testing.T) {
 	if !n.Equals(m) {
 		t.Errorf("m and n are not equal")
 	}
+
+	// The output has no newline after its last line.
+	Matf64FromData([][]float64{{1, -0.5}, {3, 4}}).ToCSV(filename)
+	b, err := os.ReadFile(filename)
+	assert.Nil(t, err, "should be readable")
+	want := "1.00000000000000e+00,-5.00000000000000e-01\n" +
+		"3.00000000000000e+00,4.00000000000000e+00"
+	assert.Equal(t, want, string(b), "should be equal")
 	os.Remove(filename)
 }
 
@@ -367,6 +421,7 @@ func TestSetf64(t *testing.T) {
 
 func TestSetColf64(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	m := Newf64(3, 4)
 	m.SetCol(-1, 3.0)
 	n := m.Col(-1)
@@ -389,16 +444,20 @@ func TestSetColf64(t *testing.T) {
 		assert.Equal(t, 0.0, n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetCol(1, 1) }, "should panic")
+	assert.NotNil(t, m.SetColE(5, 2.0), "should error")
+	assert.NotNil(t, m.SetColE(1, []float64{0.0}), "should error")
+	assert.NotNil(t, m.SetColE(1, 1), "should error")
 }
 
 func TestSetRowf64(t *testing.T) {
 	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
 	m := Newf64(3, 4)
 	m.SetRow(-1, 3.0)
 	n := m.Row(-1)
@@ -421,12 +480,15 @@ func TestSetRowf64(t *testing.T) {
 		assert.Equal(t, 0.0, n.vals[i], "should be equal")
 	}
 
-	// assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, []float64{0.0}) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
-	// assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(5, 2.0) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, []float64{0.0}) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(-1, 1) }, "should panic")
+	assert.Panics(t, func() { m.SetRow(1, 1) }, "should panic")
+	assert.NotNil(t, m.SetRowE(5, 2.0), "should error")
+	assert.NotNil(t, m.SetRowE(1, []float64{0.0}), "should error")
+	assert.NotNil(t, m.SetRowE(1, 1), "should error")
 }
 
 func TestColf64(t *testing.T) {
@@ -503,6 +565,12 @@ func TestMinf64(t *testing.T) {
 	idx, minVal = m.Min(1, 1)
 	assert.Equal(t, -100.0, minVal, "should be equal")
 	assert.Equal(t, 2, idx, "should be equal")
+	_, _, err := Newf64().MinE()
+	assert.IsType(t, &ArgumentError{}, err, "empty matrix")
+	_, _, err = Newf64(2, 0).MinE(0, 1)
+	assert.IsType(t, &ArgumentError{}, err, "empty row")
+	_, _, err = Newf64(0, 2).MinE(1, 1)
+	assert.IsType(t, &ArgumentError{}, err, "empty column")
 }
 
 func TestMaxf64(t *testing.T) {
@@ -517,6 +585,12 @@ func TestMaxf64(t *testing.T) {
 	idx, maxVal = m.Max(1, 1)
 	assert.Equal(t, 100.0, maxVal, "should be equal")
 	assert.Equal(t, 2, idx, "should be equal")
+	_, _, err := Newf64().MaxE()
+	assert.IsType(t, &ArgumentError{}, err, "empty matrix")
+	_, _, err = Newf64(2, 0).MaxE(0, 1)
+	assert.IsType(t, &ArgumentError{}, err, "empty row")
+	_, _, err = Newf64(0, 2).MaxE(1, 1)
+	assert.IsType(t, &ArgumentError{}, err, "empty column")
 }
 
 func TestEqualsf64(t *testing.T) {
@@ -665,6 +739,8 @@ func TestAddf64(t *testing.T) {
 	for i := 0; i < rows*cols; i++ {
 		assert.Equal(t, n.vals[i]+n.vals[i], m.vals[i], "should be equal")
 	}
+	assert.NotNil(t, m.AddE(Newf64(rows, rows)), "should error")
+	assert.NotNil(t, m.AddE("a"), "should error")
 }
 
 func BenchmarkAddf64(b *testing.B) {
@@ -682,6 +758,34 @@ func BenchmarkAddf64(b *testing.B) {
 	}
 }
 
+func TestBroadcastf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	m.Sub(Matf64FromData([]float64{1, 2, 3}))
+	assert.Equal(t, []float64{0, 0, 0, 3, 3, 3}, m.vals, "row vector")
+	m.Add(Matf64FromData([]float64{1, 2}, 2))
+	assert.Equal(t, []float64{1, 1, 1, 5, 5, 5}, m.vals, "column vector")
+	m.Mul(Matf64FromData([]float64{2}))
+	assert.Equal(t, []float64{2, 2, 2, 10, 10, 10}, m.vals, "1 by 1")
+	m.Div(Matf64FromData([]float64{2, 10}, 2))
+	assert.Equal(t, []float64{1, 1, 1, 1, 1, 1}, m.vals, "column vector")
+
+	// Subtract the first row from every row, through a view of m itself.
+	n := Matf64FromData([][]float64{
+		{1, 2},
+		{3, 5},
+	})
+	n.Sub(n.View(0, 1, 0, 2))
+	assert.Equal(t, []float64{0, 0, 2, 3}, n.vals, "should be equal")
+
+	assert.NotNil(t, m.AddE(Newf64(1, 2)), "wrong row length")
+	assert.NotNil(t, m.MulE(Newf64(3, 1)), "wrong column length")
+	assert.NotNil(t, Newf64(1).SubE(m), "receiver is not broadcast")
+}
+
 func TestSubf64(t *testing.T) {
 	t.Helper()
 	rows, cols := 13, 90
@@ -791,6 +895,37 @@ func TestStdf64(t *testing.T) {
 	}
 }
 
+func TestVarf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, 4, 4, 4},
+		{5, 5, 7, 9},
+	})
+	assert.InDelta(t, 4.0, m.Var(), 1e-12, "should be equal")
+	assert.InDelta(t, 2.0, m.Std(), 1e-12, "should be equal")
+	assert.InDelta(t, 32.0/7, m.VarDdof(1), 1e-12, "should be equal")
+	assert.InDelta(t, 0.75, m.Var(0, 0), 1e-12, "divides by the row length")
+	assert.InDelta(t, 2.75, m.Var(0, 1), 1e-12, "divides by the row length")
+	assert.InDelta(t, 1.0, m.VarDdof(1, 0, 0), 1e-12, "should be equal")
+	assert.InDelta(t, 2.5, m.Std(1, 3), 1e-12, "divides by the column length")
+	assert.InDelta(t, math.Sqrt(12.5), m.StdDdof(1, 1, 3), 1e-12, "should be equal")
+
+	// A large offset does not change the result of a stable algorithm.
+	n := Matf64FromData([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16})
+	assert.InDelta(t, 22.5, n.Var(), 1e-12, "should be equal")
+	assert.InDelta(t, 30.0, n.VarDdof(1), 1e-12, "should be equal")
+
+	_, err := m.VarDdofE(8)
+	assert.NotNil(t, err, "ddof must be less than the number of values")
+	_, err = m.StdDdofE(-1)
+	assert.NotNil(t, err, "ddof cannot be negative")
+	_, err = m.StdE(0, 2)
+	assert.NotNil(t, err, "row out of range")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.VarDdof(2, 1, 0) }, "ddof equals the column length")
+	assert.Panics(t, func() { m.Var(2) }, "wrong number of arguments")
+}
+
 func TestDotf64(t *testing.T) {
 	t.Helper()
 	var (
@@ -806,6 +941,8 @@ func TestDotf64(t *testing.T) {
 		n.vals[i] = float64(i)
 	}
 	o := m.Dot(n)
+	_, err := m.DotE(m)
+	assert.NotNil(t, err, "should error")
 	assert.Equal(t, row, o.r, "should be equal")
 	assert.Equal(t, row, o.c, "should be equal")
 	p := Newf64(row, row)
@@ -849,6 +986,107 @@ func BenchmarkDotf64(b *testing.B) {
 	}
 }
 
+func naiveDotf64(m, n *Matf64) *Matf64 {
+	o := Newf64(m.r, n.c)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < n.c; j++ {
+			for k := 0; k < m.c; k++ {
+				o.vals[i*o.c+j] += m.vals[i*m.c+k] * n.vals[k*n.c+j]
+			}
+		}
+	}
+	return o
+}
+
+func TestDotParallelf64(t *testing.T) {
+	t.Helper()
+	// Sizes which are not multiples of the tile sizes.
+	m := RandMatf64(131, 77, -1, 1)
+	n := RandMatf64(77, 301, -1, 1)
+	want := naiveDotf64(m, n)
+	for _, threshold := range []int{0, -1} {
+		prev := SetDotThreshold(threshold)
+		o := m.Dot(n)
+		SetDotThreshold(prev)
+		assert.InDeltaSlice(t, want.vals, o.vals, 1e-12, "should be equal")
+	}
+}
+
+func BenchmarkDotNaivef64(b *testing.B) {
+	m := Newf64(1000)
+	n := Newf64(1000)
+	for i := range m.vals {
+		m.vals[i] = float64(i + i)
+	}
+	for i := range n.vals {
+		n.vals[i] = float64(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = naiveDotf64(m, n)
+	}
+}
+
+func BenchmarkDotSerialf64(b *testing.B) {
+	m := Newf64(1000)
+	n := Newf64(1000)
+	for i := range m.vals {
+		m.vals[i] = float64(i + i)
+	}
+	for i := range n.vals {
+		n.vals[i] = float64(i)
+	}
+	defer SetDotThreshold(SetDotThreshold(-1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.Dot(n)
+	}
+}
+
+func TestGemmf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	a := RandMatf64(70, 40, -1, 1)
+	b := RandMatf64(40, 300, -1, 1)
+	c := RandMatf64(70, 300, -1, 1)
+	ops := []struct {
+		tA, tB Transpose
+		a, b   *Matf64
+	}{
+		{NoTrans, NoTrans, a, b},
+		{Trans, NoTrans, a.T(), b},
+		{NoTrans, Trans, a, b.T()},
+		{Trans, Trans, a.T(), b.T()},
+	}
+	want := naiveDotf64(a, b).Mul(2.0).Add(c.Copy().Mul(-0.5))
+	for _, op := range ops {
+		for _, threshold := range []int{0, -1} {
+			prev := SetDotThreshold(threshold)
+			dst := c.Copy()
+			dst.Gemm(op.tA, op.tB, 2, op.a, op.b, -0.5)
+			SetDotThreshold(prev)
+			assert.InDeltaSlice(t, want.vals, dst.vals, 1e-12, "should be equal")
+		}
+	}
+
+	dst := Newf64(70, 300)
+	dst.SetAll(math.NaN())
+	dst.Gemm(NoTrans, NoTrans, 1, a, b, 0)
+	assert.InDeltaSlice(t, naiveDotf64(a, b).vals, dst.vals, 1e-12, "should ignore NaN")
+
+	defer SetDotThreshold(SetDotThreshold(-1))
+	at, bt := a.T(), b.T()
+	allocs := testing.AllocsPerRun(10, func() {
+		dst.Gemm(NoTrans, Trans, 1, a, bt, 1)
+		dst.Gemm(Trans, NoTrans, 1, at, b, 1)
+	})
+	assert.Equal(t, 0.0, allocs, "should not allocate")
+	assert.Panics(t, func() { dst.Gemm(NoTrans, NoTrans, 1, a, a, 0) }, "wrong shape")
+	assert.Panics(t, func() { Newf64(3).Gemm(NoTrans, NoTrans, 1, a, b, 0) }, "wrong shape")
+	sq := Newf64(3)
+	assert.NotNil(t, sq.GemmE(NoTrans, NoTrans, 1, sq, Newf64(3), 0), "aliased")
+}
+
 func TestAppendColf64(t *testing.T) {
 	t.Helper()
 	var (
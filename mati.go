@@ -0,0 +1,718 @@
+package matrix
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+/*
+Integer is the set of element types which an IntMat can hold.
+*/
+type Integer interface {
+	~int32 | ~int64
+}
+
+/*
+IntMat is the integer counterpart of Mat. Just like Mat, it is a flat slice
+of values along with the number of rows and columns. Arithmetic on an IntMat
+is exact, and wraps around on overflow just like the arithmetic on Go
+integers. The Checked methods, such as CheckedSum, detect overflow and
+return an OverflowError instead.
+
+Mati64 and Mati32 are the instances of IntMat for int64 and int32.
+*/
+type IntMat[T Integer] struct {
+	r, c int
+	vals []T
+}
+
+/*
+Mati64 is the instance of IntMat for int64.
+*/
+type Mati64 = IntMat[int64]
+
+/*
+Mati32 is the instance of IntMat for int32.
+*/
+type Mati32 = IntMat[int32]
+
+/*
+Newi64 is the primary constructor for the "Mati64" object. It is called with
+0 to 2 integers in the same way as Newf64:
+
+	m := matrix.Newi64(x, y)
+
+m is an x by y matrix of zeros.
+*/
+func Newi64(dims ...int) *Mati64 {
+	m, err := newIntE[int64]("Newi64()", dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Newi64E is the same as Newi64, except that it returns an error instead of
+exiting the program.
+*/
+func Newi64E(dims ...int) (*Mati64, error) {
+	return newIntE[int64]("Newi64()", dims)
+}
+
+/*
+Newi32 is the primary constructor for the "Mati32" object. It is called with
+0 to 2 integers in the same way as Newf32.
+*/
+func Newi32(dims ...int) *Mati32 {
+	m, err := newIntE[int32]("Newi32()", dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Newi32E is the same as Newi32, except that it returns an error instead of
+exiting the program.
+*/
+func Newi32E(dims ...int) (*Mati32, error) {
+	return newIntE[int32]("Newi32()", dims)
+}
+
+func newIntE[T Integer](op string, dims []int) (*IntMat[T], error) {
+	switch len(dims) {
+	case 0:
+		return &IntMat[T]{0, 0, make([]T, 0)}, nil
+	case 1:
+		if err := checkDims[T](op, dims[0], dims[0]); err != nil {
+			return nil, err
+		}
+		return &IntMat[T]{dims[0], dims[0], make([]T, dims[0]*dims[0], 2*dims[0]*dims[0])}, nil
+	case 2:
+		if err := checkDims[T](op, dims[0], dims[1]); err != nil {
+			return nil, err
+		}
+		return &IntMat[T]{dims[0], dims[1], make([]T, dims[0]*dims[1], 2*dims[0]*dims[1])}, nil
+	}
+	s := "expected 0 to 2 arguments, but received %d"
+	return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+}
+
+/*
+Mati64FromData creates a Mati64 from a []int64 or a [][]int64 slice. The
+dimensions are passed in the same way as Matf64FromData. For example:
+
+	m := matrix.Mati64FromData([]int64{1, 2, 3, 4}, 2, 2)
+*/
+func Mati64FromData(oneOrTwoDSlice interface{}, dims ...int) *Mati64 {
+	m, err := intMatFromDataE[int64]("Mati64FromData()", oneOrTwoDSlice, dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Mati64FromDataE is the same as Mati64FromData, except that it returns an
+error instead of exiting the program.
+*/
+func Mati64FromDataE(oneOrTwoDSlice interface{}, dims ...int) (*Mati64, error) {
+	return intMatFromDataE[int64]("Mati64FromData()", oneOrTwoDSlice, dims)
+}
+
+/*
+Mati32FromData creates a Mati32 from a []int32 or a [][]int32 slice. The
+dimensions are passed in the same way as Matf32FromData.
+*/
+func Mati32FromData(oneOrTwoDSlice interface{}, dims ...int) *Mati32 {
+	m, err := intMatFromDataE[int32]("Mati32FromData()", oneOrTwoDSlice, dims)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+Mati32FromDataE is the same as Mati32FromData, except that it returns an
+error instead of exiting the program.
+*/
+func Mati32FromDataE(oneOrTwoDSlice interface{}, dims ...int) (*Mati32, error) {
+	return intMatFromDataE[int32]("Mati32FromData()", oneOrTwoDSlice, dims)
+}
+
+func intMatFromDataE[T Integer](op string, data interface{}, dims []int) (*IntMat[T], error) {
+	var v []T
+	var shape []int
+	switch d := data.(type) {
+	case []T:
+		v = d
+		shape = []int{1, len(d)}
+		if len(dims) == 1 {
+			shape = []int{dims[0], 1}
+		}
+	case [][]T:
+		for i := range d {
+			if len(d[i]) != len(d[0]) {
+				s := "row %d has %d values, but row 0 has %d"
+				return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, i, len(d[i]), len(d[0]))}
+			}
+			v = append(v, d[i]...)
+		}
+		shape = []int{len(d), 0}
+		if len(d) > 0 {
+			shape[1] = len(d[0])
+		}
+		if len(dims) == 1 {
+			shape = []int{dims[0], dims[0]}
+		}
+	default:
+		return nil, &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(d)}
+	}
+	switch len(dims) {
+	case 0, 1:
+	case 2:
+		shape = dims
+	default:
+		s := "expected 0 to 2 ints, but received %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	}
+	if err := checkDims[T](op, shape[0], shape[1]); err != nil {
+		return nil, err
+	}
+	if shape[0]*shape[1] != len(v) {
+		return nil, &ShapeMismatchError{Op: op, Want: shape, Got: []int{len(v)}}
+	}
+	m := &IntMat[T]{shape[0], shape[1], make([]T, len(v), 2*len(v))}
+	copy(m.vals, v)
+	return m, nil
+}
+
+/*
+Shape returns the number of rows and columns of an IntMat.
+*/
+func (m *IntMat[T]) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+Get returns the value stored in the given row and column.
+*/
+func (m *IntMat[T]) Get(r, c int) T {
+	return m.vals[r*m.c+c]
+}
+
+/*
+Set sets the value of an IntMat at a given row and column to a given value.
+*/
+func (m *IntMat[T]) Set(r, c int, val T) *IntMat[T] {
+	m.vals[r*m.c+c] = val
+	return m
+}
+
+/*
+SetAll sets all values of an IntMat to the passed value.
+*/
+func (m *IntMat[T]) SetAll(val T) *IntMat[T] {
+	for i := range m.vals {
+		m.vals[i] = val
+	}
+	return m
+}
+
+/*
+ToSlice1D returns the values contained in an IntMat as a 1D slice.
+*/
+func (m *IntMat[T]) ToSlice1D() []T {
+	s := make([]T, len(m.vals))
+	copy(s, m.vals)
+	return s
+}
+
+/*
+ToSlice2D returns the values of an IntMat as a 2D slice.
+*/
+func (m *IntMat[T]) ToSlice2D() [][]T {
+	s := make([][]T, m.r)
+	for i := range s {
+		s[i] = make([]T, m.c)
+		copy(s[i], m.vals[i*m.c:(i+1)*m.c])
+	}
+	return s
+}
+
+/*
+ToMatf64 returns a Matf64 with the same shape and values as the IntMat.
+*/
+func (m *IntMat[T]) ToMatf64() *Matf64 {
+	n := Newf64(m.r, m.c)
+	for i := range m.vals {
+		n.vals[i] = float64(m.vals[i])
+	}
+	return n
+}
+
+/*
+SetCol sets all elements in a given column to the passed value(s), in the
+same way as (*Mat).SetCol. The passed value can be an int, an int64, an int32,
+or a slice of the element type whose length is the number of rows of m.
+Negative index values are allowed.
+*/
+func (m *IntMat[T]) SetCol(col int, intOrSlice interface{}) *IntMat[T] {
+	if err := m.SetColE(col, intOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetColE is the same as SetCol, except that it returns an error instead of
+exiting the program.
+*/
+func (m *IntMat[T]) SetColE(col int, intOrSlice interface{}) error {
+	if (col >= m.c) || (col < -m.c) {
+		return &IndexOutOfRangeError{Op: "SetCol()", Axis: 1, Index: col, Bound: m.c}
+	}
+	if col < 0 {
+		col += m.c
+	}
+	if val, ok, err := intScalar[T]("SetCol()", intOrSlice); ok {
+		if err != nil {
+			return err
+		}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val
+		}
+		return nil
+	}
+	switch val := intOrSlice.(type) {
+	case []T:
+		if len(val) != m.r {
+			return &ShapeMismatchError{Op: "SetCol()", Want: []int{m.r}, Got: []int{len(val)}}
+		}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val[r]
+		}
+	default:
+		return &UnsupportedTypeError{Op: "SetCol()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+/*
+SetRow sets all elements in a given row to the passed value(s), in the same
+way as (*Mat).SetRow. The passed value can be an int, an int64, an int32, or
+a slice of the element type whose length is the number of columns of m.
+Negative index values are allowed.
+*/
+func (m *IntMat[T]) SetRow(row int, intOrSlice interface{}) *IntMat[T] {
+	if err := m.SetRowE(row, intOrSlice); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+SetRowE is the same as SetRow, except that it returns an error instead of
+exiting the program.
+*/
+func (m *IntMat[T]) SetRowE(row int, intOrSlice interface{}) error {
+	if (row >= m.r) || (row < -m.r) {
+		return &IndexOutOfRangeError{Op: "SetRow()", Axis: 0, Index: row, Bound: m.r}
+	}
+	if row < 0 {
+		row += m.r
+	}
+	if val, ok, err := intScalar[T]("SetRow()", intOrSlice); ok {
+		if err != nil {
+			return err
+		}
+		for c := 0; c < m.c; c++ {
+			m.vals[row*m.c+c] = val
+		}
+		return nil
+	}
+	switch val := intOrSlice.(type) {
+	case []T:
+		if len(val) != m.c {
+			return &ShapeMismatchError{Op: "SetRow()", Want: []int{m.c}, Got: []int{len(val)}}
+		}
+		copy(m.vals[row*m.c:(row+1)*m.c], val)
+	default:
+		return &UnsupportedTypeError{Op: "SetRow()", Type: reflect.TypeOf(val)}
+	}
+	return nil
+}
+
+// intScalar converts an int, int64, int32 or T held in an interface{} to T.
+// The second return value reports whether v held one of these types, and the
+// error is set if the value does not fit in T.
+func intScalar[T Integer](op string, v interface{}) (T, bool, error) {
+	var x int64
+	switch y := v.(type) {
+	case T:
+		return y, true, nil
+	case int:
+		x = int64(y)
+	case int64:
+		x = y
+	case int32:
+		x = int64(y)
+	default:
+		return 0, false, nil
+	}
+	if int64(T(x)) != x {
+		return 0, true, &OverflowError{Op: op, Type: reflect.TypeOf(T(0))}
+	}
+	return T(x), true, nil
+}
+
+/*
+Sum returns the sum of the elements of an IntMat. It is called in the same
+way as (*Mat).Sum:
+
+	m.Sum()     // The sum of all elements in m
+	m.Sum(0, 2) // The sum of the 3rd row
+	m.Sum(1, 0) // The sum of the first column
+
+The sum wraps around on overflow. See CheckedSum for a version which
+detects it.
+*/
+func (m *IntMat[T]) Sum(args ...int) T {
+	x, err := m.SumE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+SumE is the same as Sum, except that it returns an error instead of exiting
+the program.
+*/
+func (m *IntMat[T]) SumE(args ...int) (T, error) {
+	return m.fold("Sum()", 0, addWrapping[T], args)
+}
+
+/*
+CheckedSum is the same as SumE, except that it also returns an
+OverflowError if the sum does not fit in the element type.
+*/
+func (m *IntMat[T]) CheckedSum(args ...int) (T, error) {
+	return m.fold("CheckedSum()", 0, addChecked[T], args)
+}
+
+/*
+Prd returns the product of the elements of an IntMat. It is called in the
+same way as Sum. The product wraps around on overflow. See CheckedPrd for a
+version which detects it.
+*/
+func (m *IntMat[T]) Prd(args ...int) T {
+	x, err := m.PrdE(args...)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+PrdE is the same as Prd, except that it returns an error instead of exiting
+the program.
+*/
+func (m *IntMat[T]) PrdE(args ...int) (T, error) {
+	return m.fold("Prd()", 1, mulWrapping[T], args)
+}
+
+/*
+CheckedPrd is the same as PrdE, except that it also returns an
+OverflowError if the product does not fit in the element type.
+*/
+func (m *IntMat[T]) CheckedPrd(args ...int) (T, error) {
+	return m.fold("CheckedPrd()", 1, mulChecked[T], args)
+}
+
+// fold combines the elements of m, or of one of its rows or columns, with f,
+// starting from init. f reports whether the combination overflowed.
+func (m *IntMat[T]) fold(op string, init T, f intOp[T], args []int) (T, error) {
+	acc := init
+	start, stride, n := 0, 1, len(m.vals)
+	switch len(args) {
+	case 0:
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			if (slice >= m.r) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: op, Axis: 0, Index: slice, Bound: m.r}
+			}
+			start, stride, n = slice*m.c, 1, m.c
+		case 1:
+			if (slice >= m.c) || (slice < 0) {
+				return 0, &IndexOutOfRangeError{Op: op, Axis: 1, Index: slice, Bound: m.c}
+			}
+			start, stride, n = slice, m.c, m.r
+		default:
+			s := "the first argument must be 0 or 1, but %d was received"
+			return 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, axis)}
+		}
+	default:
+		s := "expected 0 or 2 arguments, but received %d"
+		return 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(args))}
+	}
+	for i := 0; i < n; i++ {
+		var overflow bool
+		acc, overflow = f(acc, m.vals[start+i*stride])
+		if overflow {
+			return 0, &OverflowError{Op: op, Type: reflect.TypeOf(acc)}
+		}
+	}
+	return acc, nil
+}
+
+/*
+Dot is the matrix multiplication of two IntMat objects, defined in the same
+way as (*Mat).Dot. The number of columns of m must equal the number of rows
+of n. The products and sums wrap around on overflow. See CheckedDot for a
+version which detects it.
+*/
+func (m *IntMat[T]) Dot(n *IntMat[T]) *IntMat[T] {
+	o, err := m.DotE(n)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+DotE is the same as Dot, except that it returns an error instead of exiting
+the program.
+*/
+func (m *IntMat[T]) DotE(n *IntMat[T]) (*IntMat[T], error) {
+	return m.dot("Dot()", n, addWrapping[T], mulWrapping[T])
+}
+
+/*
+CheckedDot is the same as DotE, except that it also returns an
+OverflowError if any of the intermediate products or sums does not fit in
+the element type.
+*/
+func (m *IntMat[T]) CheckedDot(n *IntMat[T]) (*IntMat[T], error) {
+	return m.dot("CheckedDot()", n, addChecked[T], mulChecked[T])
+}
+
+func (m *IntMat[T]) dot(op string, n *IntMat[T], add, mul intOp[T]) (*IntMat[T], error) {
+	if m.c != n.r {
+		return nil, &ShapeMismatchError{Op: op, Want: []int{m.c, n.c}, Got: []int{n.r, n.c}}
+	}
+	o := &IntMat[T]{m.r, n.c, make([]T, m.r*n.c, 2*m.r*n.c)}
+	for i := 0; i < m.r; i++ {
+		for k := 0; k < m.c; k++ {
+			a := m.vals[i*m.c+k]
+			for j := 0; j < n.c; j++ {
+				p, overflow := mul(a, n.vals[k*n.c+j])
+				if !overflow {
+					o.vals[i*o.c+j], overflow = add(o.vals[i*o.c+j], p)
+				}
+				if overflow {
+					return nil, &OverflowError{Op: op, Type: reflect.TypeOf(a)}
+				}
+			}
+		}
+	}
+	return o, nil
+}
+
+// intOp is a binary operation on integers, which also reports whether the
+// result overflowed.
+type intOp[T Integer] func(a, b T) (T, bool)
+
+func addWrapping[T Integer](a, b T) (T, bool) {
+	return a + b, false
+}
+
+func mulWrapping[T Integer](a, b T) (T, bool) {
+	return a * b, false
+}
+
+func addChecked[T Integer](a, b T) (T, bool) {
+	s := a + b
+	return s, (b > 0 && s < a) || (b < 0 && s > a)
+}
+
+func mulChecked[T Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	p := a * b
+	var x T
+	minT := T(-1) << (unsafe.Sizeof(x)*8 - 1)
+	if (a == -1 && b == minT) || (b == -1 && a == minT) {
+		return p, true
+	}
+	return p, p/b != a
+}
+
+/*
+AppendCol appends a column to the right side of an IntMat.
+*/
+func (m *IntMat[T]) AppendCol(v []T) *IntMat[T] {
+	if err := m.AppendColE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendColE is the same as AppendCol, except that it returns an error instead
+of exiting the program.
+*/
+func (m *IntMat[T]) AppendColE(v []T) error {
+	if m.r != len(v) {
+		return &ShapeMismatchError{Op: "AppendCol()", Want: []int{m.r}, Got: []int{len(v)}}
+	}
+	n := &IntMat[T]{len(v), 1, v}
+	return m.concat("AppendCol()", n)
+}
+
+/*
+AppendRow appends a row to the bottom of an IntMat.
+*/
+func (m *IntMat[T]) AppendRow(v []T) *IntMat[T] {
+	if err := m.AppendRowE(v); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendRowE is the same as AppendRow, except that it returns an error instead
+of exiting the program.
+*/
+func (m *IntMat[T]) AppendRowE(v []T) error {
+	if m.c != len(v) {
+		return &ShapeMismatchError{Op: "AppendRow()", Want: []int{m.c}, Got: []int{len(v)}}
+	}
+	m.vals = append(m.vals, v...)
+	m.r++
+	return nil
+}
+
+/*
+Concat merges a passed IntMat to the right side of the receiver. The passed
+IntMat must have the same number of rows as the receiver.
+*/
+func (m *IntMat[T]) Concat(n *IntMat[T]) *IntMat[T] {
+	if err := m.ConcatE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ConcatE is the same as Concat, except that it returns an error instead of
+exiting the program.
+*/
+func (m *IntMat[T]) ConcatE(n *IntMat[T]) error {
+	return m.concat("Concat()", n)
+}
+
+func (m *IntMat[T]) concat(op string, n *IntMat[T]) error {
+	if m.r != n.r {
+		return &ShapeMismatchError{Op: op, Want: []int{m.r, n.c}, Got: []int{n.r, n.c}}
+	}
+	c := m.c + n.c
+	vals := make([]T, m.r*c, 2*m.r*c)
+	for i := 0; i < m.r; i++ {
+		copy(vals[i*c:], m.vals[i*m.c:(i+1)*m.c])
+		copy(vals[i*c+m.c:], n.vals[i*n.c:(i+1)*n.c])
+	}
+	m.c, m.vals = c, vals
+	return nil
+}
+
+/*
+Append merges a passed IntMat to the bottom of the receiver. The passed
+IntMat must have the same number of columns as the receiver.
+*/
+func (m *IntMat[T]) Append(n *IntMat[T]) *IntMat[T] {
+	if err := m.AppendE(n); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+AppendE is the same as Append, except that it returns an error instead of
+exiting the program.
+*/
+func (m *IntMat[T]) AppendE(n *IntMat[T]) error {
+	if m.c != n.c {
+		return &ShapeMismatchError{Op: "Append()", Want: []int{n.r, m.c}, Got: []int{n.r, n.c}}
+	}
+	m.vals = append(m.vals, n.vals...)
+	m.r += n.r
+	return nil
+}
+
+/*
+Equals checks to see if two IntMat objects have the same shape and the same
+values.
+*/
+func (m *IntMat[T]) Equals(n *IntMat[T]) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i := range m.vals {
+		if m.vals[i] != n.vals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Copy returns a deep copy of an IntMat.
+*/
+func (m *IntMat[T]) Copy() *IntMat[T] {
+	n := &IntMat[T]{m.r, m.c, make([]T, len(m.vals), 2*len(m.vals))}
+	copy(n.vals, m.vals)
+	return n
+}
+
+/*
+T returns the transpose of an IntMat as a new IntMat.
+*/
+func (m *IntMat[T]) T() *IntMat[T] {
+	n := &IntMat[T]{m.c, m.r, make([]T, len(m.vals), 2*len(m.vals))}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			n.vals[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	return n
+}
+
+/*
+String returns the string representation of an IntMat, in the same format
+as (*Mat).String.
+*/
+func (m *IntMat[T]) String() string {
+	str := "["
+	for i := 0; i < m.r; i++ {
+		str += "["
+		for j := 0; j < m.c; j++ {
+			str += strconv.FormatInt(int64(m.vals[i*m.c+j]), 10)
+			if j+1 != m.c {
+				str += ",\t"
+			}
+		}
+		str += "]"
+		if i+1 != m.r {
+			str += "\n "
+		}
+	}
+	str += "]\n"
+	return str
+}
@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewi64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Newi64(3, 4)
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 4, m.c, "should be equal")
+	assert.Equal(t, 12, len(m.vals), "should be equal")
+	n := Newi32(5)
+	assert.Equal(t, 25, len(n.vals), "should be equal")
+	assert.Panics(t, func() { Newi64(1, 2, 3) }, "should panic with 3+ args")
+	_, err := Newi64E(-1)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newi32E(2, -3)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimension")
+	_, err = Newi64E(100000000000, 100000000000)
+	assert.IsType(t, &ArgumentError{}, err, "too large")
+}
+
+func TestMati64FromData(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Mati64FromData([]int64{1, 2, 3, 4, 5, 6}, 2, 3)
+	assert.Equal(t, int64(6), m.Get(1, 2), "should be equal")
+	m = Mati64FromData([][]int64{{1, 2}, {3, 4}})
+	assert.Equal(t, int64(3), m.Get(1, 0), "should be equal")
+	n := Mati32FromData([]int32{1, 2, 3}, 3)
+	r, c := n.Shape()
+	assert.Equal(t, 3, r, "should be a column")
+	assert.Equal(t, 1, c, "should be a column")
+	assert.Panics(t, func() { Mati64FromData([]int64{1, 2, 3}, 2, 2) }, "wrong size")
+	assert.Panics(t, func() { Mati64FromData([]int32{1}) }, "wrong type")
+	_, err := Mati64FromDataE([]int64{1, 2, 3, 4}, -2, -2)
+	assert.IsType(t, &ArgumentError{}, err, "negative dimensions")
+	_, err = Mati64FromDataE([][]int64{{1}, {2, 3}, {}})
+	assert.IsType(t, &ArgumentError{}, err, "rows of unequal length")
+}
+
+func TestSetRowColi64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Newi64(3, 2)
+	m.SetCol(-1, 7).SetRow(0, []int64{1, 2})
+	assert.Equal(t, []int64{1, 2, 0, 7, 0, 7}, m.vals, "should be equal")
+	n := Newi32(2)
+	assert.NotNil(t, n.SetRowE(0, int64(math.MaxInt64)), "should not fit in int32")
+	assert.Panics(t, func() { m.SetCol(2, 1) }, "out of bounds")
+	assert.Panics(t, func() { m.SetRow(0, 1.0) }, "wrong type")
+}
+
+func TestSumPrdi64(t *testing.T) {
+	t.Helper()
+	m := Mati64FromData([]int64{1, 2, 3, 4, 5, 6}, 2, 3)
+	assert.Equal(t, int64(21), m.Sum(), "should be equal")
+	assert.Equal(t, int64(15), m.Sum(0, 1), "should be equal")
+	assert.Equal(t, int64(9), m.Sum(1, 2), "should be equal")
+	assert.Equal(t, int64(720), m.Prd(), "should be equal")
+	assert.Equal(t, int64(18), m.Prd(1, 2), "should be equal")
+
+	big := Mati64FromData([]int64{math.MaxInt64, 1})
+	_, err := big.CheckedSum()
+	var e *OverflowError
+	assert.True(t, errors.As(err, &e), "should overflow")
+	assert.Equal(t, int64(math.MinInt64), big.Sum(), "should wrap around")
+	_, err = Mati32FromData([]int32{1 << 16, 1 << 16}).CheckedPrd()
+	assert.True(t, errors.As(err, &e), "should overflow")
+	_, err = Mati64FromData([]int64{math.MinInt64, -1}).CheckedPrd()
+	assert.True(t, errors.As(err, &e), "should overflow")
+	x, err := Mati64FromData([]int64{-3, 1 << 20}).CheckedPrd()
+	assert.Nil(t, err, "should not overflow")
+	assert.Equal(t, int64(-3<<20), x, "should be equal")
+}
+
+func TestDoti64(t *testing.T) {
+	t.Helper()
+	m := Mati64FromData([]int64{1, 2, 3, 4, 5, 6}, 2, 3)
+	o := m.Dot(m.T())
+	assert.True(t, o.Equals(Mati64FromData([]int64{14, 32, 32, 77}, 2, 2)), "should be equal")
+	_, err := m.DotE(m)
+	assert.NotNil(t, err, "should error")
+	big := Mati32FromData([]int32{1 << 20, 1 << 20}, 1, 2)
+	_, err = big.CheckedDot(big.T())
+	var e *OverflowError
+	assert.True(t, errors.As(err, &e), "should overflow")
+}
+
+func TestAppendConcati64(t *testing.T) {
+	t.Helper()
+	m := Mati64FromData([]int64{1, 2, 3, 4}, 2, 2)
+	m.AppendCol([]int64{5, 6})
+	assert.Equal(t, []int64{1, 2, 5, 3, 4, 6}, m.vals, "should be equal")
+	m.AppendRow([]int64{7, 8, 9})
+	m.Concat(Mati64FromData([]int64{0, 0, 0}, 3))
+	m.Append(Newi64(1, 4))
+	r, c := m.Shape()
+	assert.Equal(t, 4, r, "should be equal")
+	assert.Equal(t, 4, c, "should be equal")
+	assert.Equal(t, "[[1,\t2,\t5,\t0]\n [3,\t4,\t6,\t0]\n [7,\t8,\t9,\t0]\n [0,\t0,\t0,\t0]]\n",
+		m.String(), "should be equal")
+}
@@ -0,0 +1,357 @@
+package matrix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mtxBanner starts the header line of every Matrix Market file.
+const mtxBanner = "%%MatrixMarket"
+
+// mtxMaxElements is the largest number of values of a matrix read by
+// ReadMatrixMarket. The matrix is dense, while the size line of a coordinate
+// file can describe a huge matrix with few entries.
+const mtxMaxElements = 1 << 28
+
+/*
+MatrixMarketOptions configures how WriteMatrixMarket writes a Mat. The zero
+value writes all the values in the array layout, with general symmetry.
+*/
+type MatrixMarketOptions struct {
+	// Coordinate selects the coordinate layout, in which only the nonzero
+	// values are written along with their row and column. This suits sparse
+	// matrices.
+	Coordinate bool
+	// Symmetry is "general", "symmetric" or "skew-symmetric", and defaults
+	// to "general" when it is empty. Only the lower triangle of a symmetric
+	// or skew-symmetric matrix is written, so the Mat must be exactly
+	// symmetric or skew-symmetric, where NaN values match each other.
+	Symmetry string
+	// Comment, if not empty, is written after the header, one comment line
+	// for each of its lines.
+	Comment string
+}
+
+/*
+ReadMatrixMarket reads a Matf64 from the Matrix Market exchange format, which
+is used by the SuiteSparse Matrix Collection among others. Both the array
+and the coordinate layouts are supported, with real, integer and pattern
+fields, and general, symmetric and skew-symmetric symmetry. The entries of
+a pattern matrix are set to 1, and the entries which are missing from a
+coordinate matrix are 0. For example:
+
+	f, _ := os.Open("bcsstk01.mtx")
+	defer f.Close()
+	m := matrix.ReadMatrixMarket(f)
+
+Complex and hermitian matrices are not supported. Since the returned Mat is
+dense, matrices with more than 1<<28 values are refused with an error.
+*/
+func ReadMatrixMarket(r io.Reader) *Matf64 {
+	m, err := ReadMatrixMarketE(r)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadMatrixMarketE is the same as ReadMatrixMarket, except that it returns an
+error instead of exiting the program.
+*/
+func ReadMatrixMarketE(r io.Reader) (*Matf64, error) {
+	const op = "ReadMatrixMarket()"
+	sc := bufio.NewScanner(r)
+	line := 0
+	// next returns the fields of the next line which is neither empty nor a
+	// comment.
+	next := func() ([]string, error) {
+		for sc.Scan() {
+			line++
+			s := strings.TrimSpace(sc.Text())
+			if s != "" && s[0] != '%' {
+				return strings.Fields(s), nil
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		return nil, fmt.Errorf("In %s, %w", op, io.ErrUnexpectedEOF)
+	}
+
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		return nil, fmt.Errorf("In %s, %w", op, io.ErrUnexpectedEOF)
+	}
+	line++
+	banner := strings.Fields(strings.ToLower(sc.Text()))
+	if len(banner) != 5 || banner[0] != strings.ToLower(mtxBanner) || banner[1] != "matrix" {
+		s := "%q is not a valid Matrix Market header"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, sc.Text())}
+	}
+	layout, field, symmetry := banner[2], banner[3], banner[4]
+	if layout != "coordinate" && layout != "array" {
+		s := "the layout %q is not supported"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, layout)}
+	}
+	if field != "real" && field != "integer" && (field != "pattern" || layout != "coordinate") {
+		s := "the %q field is not supported with the %s layout"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, field, layout)}
+	}
+	if symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric" {
+		s := "the symmetry %q is not supported"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, symmetry)}
+	}
+
+	// badLine is the error for a line which does not hold the expected items.
+	badLine := func(fields []string, err error) error {
+		return &ParseError{Op: op, Line: line, Value: strings.Join(fields, " "), Err: err}
+	}
+	// ints parses the fields of a line of integers.
+	ints := func(fields []string, n int) ([]int, error) {
+		if len(fields) != n {
+			err := fmt.Errorf("expected %d items, but received %d", n, len(fields))
+			return nil, badLine(fields, err)
+		}
+		v := make([]int, n)
+		for i, f := range fields {
+			x, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, &ParseError{Op: op, Line: line, Item: i, Value: f, Err: err}
+			}
+			if x < 0 {
+				err := errors.New("negative sizes are not valid")
+				return nil, &ParseError{Op: op, Line: line, Item: i, Value: f, Err: err}
+			}
+			v[i] = x
+		}
+		return v, nil
+	}
+	value := func(f string, item int) (float64, error) {
+		x, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return 0, &ParseError{Op: op, Line: line, Item: item, Value: f, Err: err}
+		}
+		return x, nil
+	}
+
+	fields, err := next()
+	if err != nil {
+		return nil, err
+	}
+	n := 2
+	if layout == "coordinate" {
+		n = 3
+	}
+	size, err := ints(fields, n)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := size[0], size[1]
+	if symmetry != "general" && rows != cols {
+		s := "a %s matrix must be square, but the size is %d by %d"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, symmetry, rows, cols)}
+	}
+	if cols > 0 && rows > mtxMaxElements/cols {
+		s := "a %d by %d matrix is larger than the limit of %d values"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, rows, cols, mtxMaxElements)}
+	}
+	m, err := newE[float64](op, []int{rows, cols})
+	if err != nil {
+		return nil, err
+	}
+	set := func(i, j int, x float64) {
+		m.vals[i*cols+j] = x
+		if i != j {
+			switch symmetry {
+			case "symmetric":
+				m.vals[j*cols+i] = x
+			case "skew-symmetric":
+				m.vals[j*cols+i] = -x
+			}
+		}
+	}
+
+	if layout == "array" {
+		for j := 0; j < cols; j++ {
+			i0 := 0
+			switch symmetry {
+			case "symmetric":
+				i0 = j
+			case "skew-symmetric":
+				i0 = j + 1
+			}
+			for i := i0; i < rows; i++ {
+				fields, err := next()
+				if err != nil {
+					return nil, err
+				}
+				if len(fields) != 1 {
+					err := fmt.Errorf("expected 1 item, but received %d", len(fields))
+					return nil, badLine(fields, err)
+				}
+				x, err := value(fields[0], 0)
+				if err != nil {
+					return nil, err
+				}
+				set(i, j, x)
+			}
+		}
+	} else {
+		n := 3
+		if field == "pattern" {
+			n = 2
+		}
+		for k := 0; k < size[2]; k++ {
+			fields, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) != n {
+				err := fmt.Errorf("expected %d items, but received %d", n, len(fields))
+				return nil, badLine(fields, err)
+			}
+			idx, err := ints(fields[:2], 2)
+			if err != nil {
+				return nil, err
+			}
+			i, j := idx[0]-1, idx[1]-1
+			if i < 0 || i >= rows || j < 0 || j >= cols {
+				err := fmt.Errorf("the entry is outside of the %d by %d matrix", rows, cols)
+				return nil, badLine(fields, err)
+			}
+			x := 1.0
+			if field != "pattern" {
+				if x, err = value(fields[2], 2); err != nil {
+					return nil, err
+				}
+			}
+			set(i, j, x)
+		}
+	}
+	for sc.Scan() {
+		line++
+		s := strings.TrimSpace(sc.Text())
+		if s != "" && s[0] != '%' {
+			err := errors.New("there are more entries than the size line declares")
+			return nil, &ParseError{Op: op, Line: line, Value: s, Err: err}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	return m, nil
+}
+
+/*
+WriteMatrixMarket writes a Mat to the passed io.Writer in the Matrix Market
+exchange format, with a real field, as configured by the passed options,
+which can be nil to use the defaults. For example, to write the nonzero
+values of the lower triangle of a symmetric matrix:
+
+	m.WriteMatrixMarket(f, &matrix.MatrixMarketOptions{
+		Coordinate: true,
+		Symmetry:   "symmetric",
+	})
+*/
+func (m *Mat[T]) WriteMatrixMarket(w io.Writer, opts *MatrixMarketOptions) {
+	if err := m.WriteMatrixMarketE(w, opts); err != nil {
+		handleErr(err)
+	}
+}
+
+/*
+WriteMatrixMarketE is the same as WriteMatrixMarket, except that it returns
+an error instead of exiting the program.
+*/
+func (m *Mat[T]) WriteMatrixMarketE(w io.Writer, opts *MatrixMarketOptions) error {
+	const op = "WriteMatrixMarket()"
+	if opts == nil {
+		opts = &MatrixMarketOptions{}
+	}
+	symmetry := opts.Symmetry
+	if symmetry == "" {
+		symmetry = "general"
+	}
+	// lower is the first row of column j which is written.
+	lower := func(j int) int { return 0 }
+	switch symmetry {
+	case "general":
+	case "symmetric", "skew-symmetric":
+		sign := T(1)
+		lower = func(j int) int { return j }
+		if symmetry == "skew-symmetric" {
+			sign = -1
+			lower = func(j int) int { return j + 1 }
+		}
+		if m.r != m.c {
+			return &ShapeMismatchError{Op: op, Want: []int{m.r, m.r}, Got: []int{m.r, m.c}}
+		}
+		for i := 0; i < m.r; i++ {
+			for j := 0; j <= i; j++ {
+				x, y := m.vals[i*m.stride+j], sign*m.vals[j*m.stride+i]
+				// Two NaNs match, unless they are on the diagonal of a
+				// skew-symmetric matrix, which is not written.
+				nan := x != x && y != y && i >= lower(j)
+				if x != y && !nan {
+					s := "the matrix is not %s at row %d and column %d"
+					return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, symmetry, i, j)}
+				}
+			}
+		}
+	default:
+		s := "the symmetry %q is not supported"
+		return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, symmetry)}
+	}
+
+	layout := "array"
+	if opts.Coordinate {
+		layout = "coordinate"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix %s real %s\n", mtxBanner, layout, symmetry)
+	if opts.Comment != "" {
+		for _, s := range strings.Split(opts.Comment, "\n") {
+			fmt.Fprintf(bw, "%%%s\n", s)
+		}
+	}
+	format := func(v T) []byte {
+		return strconv.AppendFloat(nil, float64(v), 'g', -1, bitSize[T]())
+	}
+	if opts.Coordinate {
+		nnz := 0
+		for j := 0; j < m.c; j++ {
+			for i := lower(j); i < m.r; i++ {
+				if m.vals[i*m.stride+j] != 0 {
+					nnz++
+				}
+			}
+		}
+		fmt.Fprintf(bw, "%d %d %d\n", m.r, m.c, nnz)
+		for j := 0; j < m.c; j++ {
+			for i := lower(j); i < m.r; i++ {
+				if v := m.vals[i*m.stride+j]; v != 0 {
+					fmt.Fprintf(bw, "%d %d %s\n", i+1, j+1, format(v))
+				}
+			}
+		}
+	} else {
+		fmt.Fprintf(bw, "%d %d\n", m.r, m.c)
+		for j := 0; j < m.c; j++ {
+			for i := lower(j); i < m.r; i++ {
+				bw.Write(format(m.vals[i*m.stride+j]))
+				bw.WriteByte('\n')
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("In %s, %w", op, err)
+	}
+	return nil
+}
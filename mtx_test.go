@@ -0,0 +1,145 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	t.Helper()
+	in := `%%MatrixMarket matrix coordinate real general
+% A 3 by 4 sparse matrix.
+%
+3 4 4
+1 1 1.5
+2 3 -2
+3 4 1e2
+
+3 1 7
+`
+	m := ReadMatrixMarket(strings.NewReader(in))
+	assert.Equal(t, [][]float64{
+		{1.5, 0, 0, 0},
+		{0, 0, -2, 0},
+		{7, 0, 0, 100},
+	}, m.ToSlice2D(), "coordinate general")
+
+	in = "%%MatrixMarket matrix coordinate pattern symmetric\n3 3 3\n1 1\n3 1\n3 2\n"
+	m = ReadMatrixMarket(strings.NewReader(in))
+	assert.Equal(t, [][]float64{{1, 0, 1}, {0, 0, 1}, {1, 1, 0}}, m.ToSlice2D(),
+		"coordinate pattern symmetric")
+
+	in = "%%MatrixMarket matrix array integer general\n2 3\n1\n4\n2\n5\n3\n6\n"
+	m = ReadMatrixMarket(strings.NewReader(in))
+	assert.Equal(t, [][]float64{{1, 2, 3}, {4, 5, 6}}, m.ToSlice2D(), "array in column order")
+
+	in = "%%MatrixMarket matrix array real symmetric\n2 2\n1\n2\n3\n"
+	m = ReadMatrixMarket(strings.NewReader(in))
+	assert.Equal(t, [][]float64{{1, 2}, {2, 3}}, m.ToSlice2D(), "array symmetric")
+
+	in = "%%MATRIXMARKET MATRIX ARRAY REAL SKEW-SYMMETRIC\n3 3\n1\n2\n3\n"
+	m = ReadMatrixMarket(strings.NewReader(in))
+	assert.Equal(t, [][]float64{{0, -1, -2}, {1, 0, -3}, {2, 3, 0}}, m.ToSlice2D(),
+		"array skew-symmetric")
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	t.Helper()
+	bad := map[string]string{
+		"empty":             "",
+		"no banner":         "3 3 1\n1 1 1\n",
+		"complex":           "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+		"hermitian":         "%%MatrixMarket matrix coordinate real hermitian\n1 1 1\n1 1 1\n",
+		"array pattern":     "%%MatrixMarket matrix array pattern general\n1 1\n",
+		"not square":        "%%MatrixMarket matrix array real symmetric\n2 3\n",
+		"bad size":          "%%MatrixMarket matrix coordinate real general\n2 x 1\n",
+		"out of range":      "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"too few entries":   "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"too many entries":  "%%MatrixMarket matrix array real general\n1 1\n1\n2\n",
+		"bad value":         "%%MatrixMarket matrix array real general\n1 1\nabc\n",
+		"missing value":     "%%MatrixMarket matrix coordinate real general\n1 1 1\n1 1\n",
+		"two values a line": "%%MatrixMarket matrix array real general\n1 2\n1 2\n",
+		"overflowing size":  "%%MatrixMarket matrix coordinate real general\n4611686018427387904 4 0\n",
+		"huge size":         "%%MatrixMarket matrix coordinate real general\n100000000000 100000000000 0\n",
+		"too large":         "%%MatrixMarket matrix coordinate real general\n100000 100000 0\n",
+	}
+	for name, in := range bad {
+		_, err := ReadMatrixMarketE(strings.NewReader(in))
+		assert.NotNil(t, err, name)
+	}
+	_, err := ReadMatrixMarketE(strings.NewReader(bad["bad value"]))
+	var perr *ParseError
+	assert.True(t, errors.As(err, &perr), "should be a ParseError")
+	assert.Equal(t, 3, perr.Line, "should be equal")
+
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { ReadMatrixMarket(strings.NewReader(bad["complex"])) }, "complex")
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{4, 1, 0},
+		{1, 0, 0.5},
+		{0, 0.5, 2},
+	})
+	var b bytes.Buffer
+	m.WriteMatrixMarket(&b, &MatrixMarketOptions{
+		Coordinate: true,
+		Symmetry:   "symmetric",
+		Comment:    " written by a test",
+	})
+	want := `%%MatrixMarket matrix coordinate real symmetric
+% written by a test
+3 3 4
+1 1 4
+2 1 1
+3 2 0.5
+3 3 2
+`
+	assert.Equal(t, want, b.String(), "should be equal")
+	assert.True(t, m.Equals(ReadMatrixMarket(&b)), "round trip")
+
+	b.Reset()
+	n := Matf32FromData([][]float32{{0.1, 2}, {3, 4}})
+	n.WriteMatrixMarket(&b, nil)
+	want = "%%MatrixMarket matrix array real general\n2 2\n0.1\n3\n2\n4\n"
+	assert.Equal(t, want, b.String(), "array in column order")
+
+	s := Matf64FromData([][]float64{{0, -1}, {1, 0}})
+	for _, coord := range []bool{false, true} {
+		b.Reset()
+		opts := &MatrixMarketOptions{Coordinate: coord, Symmetry: "skew-symmetric"}
+		s.WriteMatrixMarket(&b, opts)
+		assert.True(t, s.Equals(ReadMatrixMarket(&b)), "skew-symmetric round trip")
+	}
+
+	v := rangeMatf64(4, 4).View(1, 3, 0, 3)
+	b.Reset()
+	v.WriteMatrixMarket(&b, &MatrixMarketOptions{Coordinate: true})
+	assert.True(t, v.Equals(ReadMatrixMarket(&b)), "view round trip")
+
+	opts := &MatrixMarketOptions{Symmetry: "symmetric"}
+	b.Reset()
+	nan := Matf64FromData([][]float64{{1, math.NaN()}, {math.NaN(), math.NaN()}})
+	assert.Nil(t, nan.WriteMatrixMarketE(&b, opts), "NaN values match")
+	want = "%%MatrixMarket matrix array real symmetric\n2 2\n1\nNaN\nNaN\n"
+	assert.Equal(t, want, b.String(), "should be equal")
+	opts.Symmetry = "skew-symmetric"
+	nan = Matf64FromData([][]float64{{0, math.NaN()}, {math.NaN(), 0}})
+	assert.Nil(t, nan.WriteMatrixMarketE(&b, opts), "NaN values match")
+	assert.NotNil(t, Matf64FromData([]float64{math.NaN()}).WriteMatrixMarketE(&b, opts),
+		"NaN on a skew-symmetric diagonal")
+	opts.Symmetry = "symmetric"
+	assert.NotNil(t, s.WriteMatrixMarketE(&b, opts), "not symmetric")
+	assert.NotNil(t, v.WriteMatrixMarketE(&b, opts), "not square")
+	opts.Symmetry = "skew-symmetric"
+	assert.NotNil(t, m.WriteMatrixMarketE(&b, opts), "not skew-symmetric")
+	opts.Symmetry = "hermitian"
+	assert.NotNil(t, m.WriteMatrixMarketE(&b, opts), "unsupported symmetry")
+}
@@ -0,0 +1,406 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// npyMagic starts every .npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyChunk is the number of bytes of values read from a .npy file at a time,
+// and npyMaxHeader is the length of the longest header which is accepted.
+const (
+	npyChunk     = 1 << 16
+	npyMaxHeader = 1 << 16
+)
+
+var (
+	npyDescr   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortran = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+/*
+ReadNpyf64 reads a Matf64 from the NumPy .npy format, as written by
+numpy.save. The array must hold float64 or float32 values, in either byte
+order and in either C or Fortran order, and is converted to float64 when
+needed. A 2-D array of shape (r, c) becomes an r by c matrix, while a 1-D
+array of length n becomes a 1 by n matrix. For example:
+
+	f, _ := os.Open("weights.npy")
+	defer f.Close()
+	m := matrix.ReadNpyf64(f)
+*/
+func ReadNpyf64(r io.Reader) *Matf64 {
+	m, err := readNpyE[float64]("ReadNpyf64()", r)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpyf64E is the same as ReadNpyf64, except that it returns an error
+instead of exiting the program.
+*/
+func ReadNpyf64E(r io.Reader) (*Matf64, error) {
+	return readNpyE[float64]("ReadNpyf64()", r)
+}
+
+/*
+ReadNpyf32 is the same as ReadNpyf64, but returns a Matf32. float64 arrays
+are rounded to float32.
+*/
+func ReadNpyf32(r io.Reader) *Matf32 {
+	m, err := readNpyE[float32]("ReadNpyf32()", r)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpyf32E is the same as ReadNpyf32, except that it returns an error
+instead of exiting the program.
+*/
+func ReadNpyf32E(r io.Reader) (*Matf32, error) {
+	return readNpyE[float32]("ReadNpyf32()", r)
+}
+
+/*
+ReadNpy is the generic counterpart of ReadNpyf64 and ReadNpyf32.
+*/
+func ReadNpy[T Float](r io.Reader) *Mat[T] {
+	m, err := readNpyE[T]("ReadNpy()", r)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpyE is the same as ReadNpy, except that it returns an error instead of
+exiting the program.
+*/
+func ReadNpyE[T Float](r io.Reader) (*Mat[T], error) {
+	return readNpyE[T]("ReadNpy()", r)
+}
+
+/*
+WriteNpy writes a Mat to the passed io.Writer in the NumPy .npy format, so
+that it can be loaded with numpy.load. The values are written in little
+endian byte order and C order, as float64 for a Matf64 and as float32 for a
+Matf32.
+*/
+func (m *Mat[T]) WriteNpy(w io.Writer) {
+	if err := m.WriteNpyE(w); err != nil {
+		handleErr(err)
+	}
+}
+
+/*
+WriteNpyE is the same as WriteNpy, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) WriteNpyE(w io.Writer) error {
+	return m.writeNpy("WriteNpy()", w)
+}
+
+/*
+ReadNpzf64 reads all the arrays of a NumPy .npz archive, as written by
+numpy.savez or numpy.savez_compressed, into Matf64s, in the same way as
+ReadNpyf64. The returned map is keyed by the names of the arrays, which are
+the names of the files in the archive without their ".npy" extension. Since
+a zip archive is read from its end, the size of the archive must be passed:
+
+	f, _ := os.Open("model.npz")
+	defer f.Close()
+	info, _ := f.Stat()
+	mats := matrix.ReadNpzf64(f, info.Size())
+	w := mats["weights"]
+*/
+func ReadNpzf64(r io.ReaderAt, size int64) map[string]*Matf64 {
+	m, err := readNpzE[float64]("ReadNpzf64()", r, size)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpzf64E is the same as ReadNpzf64, except that it returns an error
+instead of exiting the program.
+*/
+func ReadNpzf64E(r io.ReaderAt, size int64) (map[string]*Matf64, error) {
+	return readNpzE[float64]("ReadNpzf64()", r, size)
+}
+
+/*
+ReadNpzf32 is the same as ReadNpzf64, but returns Matf32s.
+*/
+func ReadNpzf32(r io.ReaderAt, size int64) map[string]*Matf32 {
+	m, err := readNpzE[float32]("ReadNpzf32()", r, size)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpzf32E is the same as ReadNpzf32, except that it returns an error
+instead of exiting the program.
+*/
+func ReadNpzf32E(r io.ReaderAt, size int64) (map[string]*Matf32, error) {
+	return readNpzE[float32]("ReadNpzf32()", r, size)
+}
+
+/*
+ReadNpz is the generic counterpart of ReadNpzf64 and ReadNpzf32.
+*/
+func ReadNpz[T Float](r io.ReaderAt, size int64) map[string]*Mat[T] {
+	m, err := readNpzE[T]("ReadNpz()", r, size)
+	if err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+ReadNpzE is the same as ReadNpz, except that it returns an error instead of
+exiting the program.
+*/
+func ReadNpzE[T Float](r io.ReaderAt, size int64) (map[string]*Mat[T], error) {
+	return readNpzE[T]("ReadNpz()", r, size)
+}
+
+/*
+WriteNpz writes the passed matrices to a NumPy .npz archive, in the same way
+as numpy.savez. Each matrix is stored under its key in the map, and is
+written as by WriteNpy. For example:
+
+	matrix.WriteNpz(f, map[string]*matrix.Matf64{"weights": w, "bias": b})
+
+can be loaded in Python with numpy.load(f)["weights"].
+*/
+func WriteNpz[T Float](w io.Writer, mats map[string]*Mat[T]) {
+	if err := WriteNpzE(w, mats); err != nil {
+		handleErr(err)
+	}
+}
+
+/*
+WriteNpzE is the same as WriteNpz, except that it returns an error instead
+of exiting the program.
+*/
+func WriteNpzE[T Float](w io.Writer, mats map[string]*Mat[T]) error {
+	const op = "WriteNpz()"
+	names := make([]string, 0, len(mats))
+	for name := range mats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return fmt.Errorf("In %s, %w", op, err)
+		}
+		if err := mats[name].writeNpy(op, f); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("In %s, %w", op, err)
+	}
+	return nil
+}
+
+func readNpzE[T Float](op string, r io.ReaderAt, size int64) (map[string]*Mat[T], error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	mats := make(map[string]*Mat[T], len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		m, err := readNpyE[T](op, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		mats[strings.TrimSuffix(f.Name, ".npy")] = m
+	}
+	return mats, nil
+}
+
+func readNpyE[T Float](op string, r io.Reader) (*Mat[T], error) {
+	pre := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	if string(pre[:len(npyMagic)]) != npyMagic {
+		return nil, &ArgumentError{Op: op, Msg: "the input is not in the .npy format"}
+	}
+	var hlen int
+	switch major := pre[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		hlen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		hlen = int(n)
+	default:
+		s := "version %d of the .npy format is not supported"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, major)}
+	}
+	if hlen > npyMaxHeader {
+		s := "the header length %d is larger than the limit of %d bytes"
+		return nil, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, hlen, npyMaxHeader)}
+	}
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("In %s, %w", op, err)
+	}
+	order, size, fortran, rows, cols, err := parseNpyHeader(op, string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkDims[T](op, rows, cols); err != nil {
+		return nil, err
+	}
+	// The values are read in chunks, so that memory is only allocated for the
+	// values which are actually present in the input, whatever the shape in
+	// the header claims.
+	n := rows * cols
+	vals := make([]T, 0, minInt(n, npyChunk/size))
+	buf := make([]byte, npyChunk)
+	for len(vals) < n {
+		k := minInt(n-len(vals), npyChunk/size)
+		if _, err := io.ReadFull(r, buf[:k*size]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("In %s, %w", op, err)
+		}
+		for i := 0; i < k; i++ {
+			if size == 8 {
+				vals = append(vals, T(math.Float64frombits(order.Uint64(buf[i*8:]))))
+			} else {
+				vals = append(vals, T(math.Float32frombits(order.Uint32(buf[i*4:]))))
+			}
+		}
+	}
+	if fortran {
+		// The values are in column major order, which is the row major order
+		// of the transpose.
+		return (&Mat[T]{r: cols, c: rows, stride: rows, vals: vals}).T(), nil
+	}
+	m := &Mat[T]{r: rows, c: cols, stride: cols, vals: vals}
+	return m, nil
+}
+
+// parseNpyHeader parses the Python dictionary literal which describes the
+// array of a .npy file.
+func parseNpyHeader(op, header string) (
+	order binary.ByteOrder, size int, fortran bool, rows, cols int, err error,
+) {
+	descr := npyDescr.FindStringSubmatch(header)
+	fo := npyFortran.FindStringSubmatch(header)
+	shape := npyShape.FindStringSubmatch(header)
+	if descr == nil || fo == nil || shape == nil {
+		s := "the header %q is not a valid .npy header"
+		return nil, 0, false, 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, header)}
+	}
+	switch descr[1] {
+	case "<f8", "=f8", "<f4", "=f4":
+		order = binary.LittleEndian
+	case ">f8", ">f4":
+		order = binary.BigEndian
+	default:
+		s := "the data type %q is not supported, only float32 and float64 are"
+		return nil, 0, false, 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, descr[1])}
+	}
+	size, _ = strconv.Atoi(descr[1][2:])
+	fortran = fo[1] == "True"
+
+	var dims []int
+	for _, d := range strings.Split(shape[1], ",") {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(d, "L"))
+		if err != nil || n < 0 {
+			s := "the shape (%s) is not valid"
+			return nil, 0, false, 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, shape[1])}
+		}
+		dims = append(dims, n)
+	}
+	switch len(dims) {
+	case 1:
+		rows, cols = 1, dims[0]
+	case 2:
+		rows, cols = dims[0], dims[1]
+	default:
+		s := "only 1-D and 2-D arrays are supported, but the array has %d dimensions"
+		return nil, 0, false, 0, 0, &ArgumentError{Op: op, Msg: fmt.Sprintf(s, len(dims))}
+	}
+	return order, size, fortran, rows, cols, nil
+}
+
+func (m *Mat[T]) writeNpy(op string, w io.Writer) error {
+	size := bitSize[T]() / 8
+	header := fmt.Sprintf("{'descr': '<f%d', 'fortran_order': False, 'shape': (%d, %d), }",
+		size, m.r, m.c)
+	// The data is aligned on 64 bytes, and the header ends with a newline.
+	total := len(npyMagic) + 4 + len(header) + 1
+	pad := (64 - total%64) % 64
+	var b bytes.Buffer
+	b.WriteString(npyMagic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)+pad+1))
+	b.WriteString(header)
+	b.WriteString(strings.Repeat(" ", pad))
+	b.WriteByte('\n')
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(b.Bytes()); err != nil {
+		return fmt.Errorf("In %s, %w", op, err)
+	}
+	row := make([]byte, m.c*size)
+	for i := 0; i < m.r; i++ {
+		for j, v := range m.row(i) {
+			if size == 8 {
+				binary.LittleEndian.PutUint64(row[j*8:], math.Float64bits(float64(v)))
+			} else {
+				binary.LittleEndian.PutUint32(row[j*4:], math.Float32bits(float32(v)))
+			}
+		}
+		if _, err := bw.Write(row); err != nil {
+			return fmt.Errorf("In %s, %w", op, err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("In %s, %w", op, err)
+	}
+	return nil
+}
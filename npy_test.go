@@ -0,0 +1,146 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// npyFile builds a version 1.0 .npy file with the passed header and data.
+func npyFile(header string, order binary.ByteOrder, data interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)+1))
+	b.WriteString(header + "\n")
+	binary.Write(&b, order, data)
+	return b.Bytes()
+}
+
+func TestWriteNpyf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{0, 1, 2},
+		{3, 4, 5},
+	})
+	var b bytes.Buffer
+	m.WriteNpy(&b)
+	// This is the header written by numpy.save(f, numpy.arange(6.).reshape(2, 3)).
+	header := "\x93NUMPY\x01\x00\x76\x00{'descr': '<f8', 'fortran_order': False, " +
+		"'shape': (2, 3), }" + strings.Repeat(" ", 58) + "\n"
+	assert.Equal(t, 128, len(header), "should be equal")
+	assert.Equal(t, header, b.String()[:128], "should be equal")
+	assert.Equal(t, 128+6*8, b.Len(), "should be equal")
+	assert.True(t, m.Equals(ReadNpyf64(&b)), "round trip")
+
+	v := rangeMatf64(4, 4).View(1, 3, 2, 4)
+	b.Reset()
+	v.WriteNpy(&b)
+	assert.True(t, v.Equals(ReadNpyf64(&b)), "view")
+}
+
+func TestReadNpyf64(t *testing.T) {
+	t.Helper()
+	want := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	vals := []float64{1, 2, 3, 4, 5, 6}
+	c := npyFile("{'descr': '>f8', 'fortran_order': False, 'shape': (2, 3), }",
+		binary.BigEndian, vals)
+	assert.Equal(t, want, ReadNpyf64(bytes.NewReader(c)).ToSlice2D(), "big endian")
+
+	f := npyFile("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 3), }",
+		binary.LittleEndian, []float32{1, 4, 2, 5, 3, 6})
+	assert.Equal(t, want, ReadNpyf64(bytes.NewReader(f)).ToSlice2D(), "Fortran order")
+
+	v := npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (6,), }",
+		binary.LittleEndian, vals)
+	assert.Equal(t, [][]float64{vals}, ReadNpyf64(bytes.NewReader(v)).ToSlice2D(), "1-D")
+
+	bad := []string{
+		"{'descr': '<i8', 'fortran_order': False, 'shape': (2, 3), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1, 2, 3), }",
+		"{'descr': '<f8', 'shape': (2, 3), }",
+	}
+	for _, h := range bad {
+		_, err := ReadNpyf64E(bytes.NewReader(npyFile(h, binary.LittleEndian, vals)))
+		assert.NotNil(t, err, h)
+	}
+	_, err := ReadNpyf64E(bytes.NewReader(c[:len(c)-1]))
+	assert.NotNil(t, err, "short data")
+
+	// Shapes which overflow, or which the data does not back, are errors.
+	shapes := []string{"(4611686018427387904, 4)", "(1000000000, 1000)", "(-1, 6)"}
+	for _, shape := range shapes {
+		h := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }"
+		_, err = ReadNpyf64E(bytes.NewReader(npyFile(h, binary.LittleEndian, vals)))
+		assert.NotNil(t, err, shape)
+	}
+	// A version 2.0 header claiming to be 1<<17 bytes long.
+	long := []byte("\x93NUMPY\x02\x00\x00\x00\x02\x00")
+	_, err = ReadNpyf64E(bytes.NewReader(long))
+	assert.IsType(t, &ArgumentError{}, err, "header too long")
+
+	// Values spanning several chunks of input, in either order.
+	big := RandMatf64(101, 103)
+	for _, fortran := range []bool{false, true} {
+		data, fo := big.ToSlice1D(), "False"
+		if fortran {
+			data, fo = big.T().ToSlice1D(), "True"
+		}
+		h := "{'descr': '>f8', 'fortran_order': " + fo + ", 'shape': (101, 103), }"
+		n := ReadNpyf64(bytes.NewReader(npyFile(h, binary.BigEndian, data)))
+		assert.True(t, big.Equals(n), "several chunks")
+	}
+	_, err = ReadNpyf64E(strings.NewReader("1,2,3\n"))
+	assert.NotNil(t, err, "not a .npy file")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { ReadNpyf64(strings.NewReader("")) }, "empty input")
+}
+
+func TestNpyf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([]float32{0.1, -2, float32(math.Inf(1)), 4}, 2, 2)
+	var b bytes.Buffer
+	m.WriteNpy(&b)
+	assert.Contains(t, b.String(), "'descr': '<f4'", "should be equal")
+	assert.Equal(t, 0, (b.Len()-4*4)%64, "the data is aligned")
+	assert.True(t, m.Equals(ReadNpyf32(&b)), "round trip")
+
+	d := npyFile("{'descr': '>f8', 'fortran_order': True, 'shape': (1, 2), }",
+		binary.BigEndian, []float64{0.5, 1.5})
+	assert.Equal(t, []float32{0.5, 1.5}, ReadNpy[float32](bytes.NewReader(d)).vals, "float64")
+}
+
+func TestNpzf64(t *testing.T) {
+	t.Helper()
+	w := RandMatf64(3, 4)
+	bias := Matf64FromData([]float64{1, 2, 3})
+	var b bytes.Buffer
+	WriteNpz(&b, map[string]*Matf64{"weights": w, "bias": bias})
+	mats := ReadNpzf64(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assert.Equal(t, 2, len(mats), "should be equal")
+	assert.True(t, w.Equals(mats["weights"]), "round trip")
+	assert.True(t, bias.Equals(mats["bias"]), "round trip")
+
+	// Archives written by numpy.savez_compressed are deflated.
+	b.Reset()
+	zw := zip.NewWriter(&b)
+	f, _ := zw.Create("x.npy")
+	w.WriteNpy(f)
+	zw.Close()
+	m32 := ReadNpzf32(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assert.Equal(t, float32(w.Get(2, 3)), m32["x"].Get(2, 3), "should be equal")
+
+	_, err := ReadNpzf64E(strings.NewReader("not a zip"), 9)
+	assert.NotNil(t, err, "not a zip archive")
+	b.Reset()
+	zw = zip.NewWriter(&b)
+	f, _ = zw.Create("x.npy")
+	f.Write([]byte("garbage"))
+	zw.Close()
+	_, err = ReadNpzE[float64](bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assert.NotNil(t, err, "not a .npy file")
+}
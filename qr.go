@@ -0,0 +1,258 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+QR is the QR factorization of an r by c matrix A, computed with Householder
+reflections, such that A * P = Q * R. Q is an r by k matrix with orthonormal
+columns and R is a k by c upper triangular matrix, where k is the smaller of
+r and c. P is a column permutation, which is the identity unless the
+factorization was created with PivotedQR. A QR is created with the QR or the
+PivotedQR method of Matf64 or Matf32:
+
+	x, err := m.QR().LeastSquaresE(b)
+*/
+type QR[T Float] struct {
+	qr      *Mat[T]
+	tau     []T
+	piv     []int
+	pivoted bool
+}
+
+/*
+QR computes the QR factorization of a matrix without column pivoting. The
+receiver is not modified.
+*/
+func (m *Mat[T]) QR() *QR[T] {
+	return m.qr(false)
+}
+
+/*
+PivotedQR computes the QR factorization of a matrix with column pivoting,
+where at each step the remaining column with the largest norm is moved to
+the front. This reveals the numerical rank of the matrix, and allows
+LeastSquares to handle rank deficient inputs. The receiver is not modified.
+*/
+func (m *Mat[T]) PivotedQR() *QR[T] {
+	return m.qr(true)
+}
+
+func (m *Mat[T]) qr(pivot bool) *QR[T] {
+	k := m.r
+	if m.c < k {
+		k = m.c
+	}
+	f := &QR[T]{qr: m.Copy(), tau: make([]T, k), piv: make([]int, m.c), pivoted: pivot}
+	a := f.qr.vals
+	r, c := m.r, m.c
+	for j := range f.piv {
+		f.piv[j] = j
+	}
+	for j := 0; j < k; j++ {
+		if pivot {
+			p, max := j, -1.0
+			for jj := j; jj < c; jj++ {
+				var s float64
+				for i := j; i < r; i++ {
+					s += float64(a[i*c+jj]) * float64(a[i*c+jj])
+				}
+				if s > max {
+					p, max = jj, s
+				}
+			}
+			if p != j {
+				for i := 0; i < r; i++ {
+					a[i*c+p], a[i*c+j] = a[i*c+j], a[i*c+p]
+				}
+				f.piv[p], f.piv[j] = f.piv[j], f.piv[p]
+			}
+		}
+		var norm float64
+		for i := j; i < r; i++ {
+			norm = math.Hypot(norm, float64(a[i*c+j]))
+		}
+		if norm == 0 {
+			continue
+		}
+		x0 := a[j*c+j]
+		beta := T(-math.Copysign(norm, float64(x0)))
+		f.tau[j] = (beta - x0) / beta
+		scale := 1 / (x0 - beta)
+		for i := j + 1; i < r; i++ {
+			a[i*c+j] *= scale
+		}
+		a[j*c+j] = beta
+		for jj := j + 1; jj < c; jj++ {
+			s := a[j*c+jj]
+			for i := j + 1; i < r; i++ {
+				s += a[i*c+j] * a[i*c+jj]
+			}
+			s *= f.tau[j]
+			a[j*c+jj] -= s
+			for i := j + 1; i < r; i++ {
+				a[i*c+jj] -= s * a[i*c+j]
+			}
+		}
+	}
+	return f
+}
+
+// applyQT overwrites b, which has as many rows as the factorized matrix,
+// with transpose(Q) * b.
+func (f *QR[T]) applyQT(b *Mat[T]) {
+	for j := range f.tau {
+		f.reflect(j, b)
+	}
+}
+
+// reflect applies the j-th Householder reflection to b.
+func (f *QR[T]) reflect(j int, b *Mat[T]) {
+	a, c := f.qr.vals, f.qr.c
+	if f.tau[j] == 0 {
+		return
+	}
+	for jj := 0; jj < b.c; jj++ {
+		s := b.vals[j*b.c+jj]
+		for i := j + 1; i < b.r; i++ {
+			s += a[i*c+j] * b.vals[i*b.c+jj]
+		}
+		s *= f.tau[j]
+		b.vals[j*b.c+jj] -= s
+		for i := j + 1; i < b.r; i++ {
+			b.vals[i*b.c+jj] -= s * a[i*c+j]
+		}
+	}
+}
+
+/*
+Q returns the r by k matrix with orthonormal columns of the factorization.
+*/
+func (f *QR[T]) Q() *Mat[T] {
+	k := len(f.tau)
+	q := New[T](f.qr.r, k)
+	for i := 0; i < k; i++ {
+		q.vals[i*k+i] = 1
+	}
+	for j := k - 1; j >= 0; j-- {
+		f.reflect(j, q)
+	}
+	return q
+}
+
+/*
+R returns the k by c upper triangular matrix of the factorization.
+*/
+func (f *QR[T]) R() *Mat[T] {
+	k, c := len(f.tau), f.qr.c
+	r := New[T](k, c)
+	for i := 0; i < k; i++ {
+		copy(r.vals[i*c+i:(i+1)*c], f.qr.vals[i*c+i:(i+1)*c])
+	}
+	return r
+}
+
+/*
+Pivot returns the column permutation of the factorization. Column j of
+A * P is column Pivot()[j] of A.
+*/
+func (f *QR[T]) Pivot() []int {
+	p := make([]int, len(f.piv))
+	copy(p, f.piv)
+	return p
+}
+
+// tol returns the threshold below which a diagonal value of R is treated
+// as zero.
+func (f *QR[T]) tol() float64 {
+	eps := epsilon[T]()
+	var max float64
+	for i := range f.tau {
+		max = math.Max(max, math.Abs(float64(f.qr.vals[i*f.qr.c+i])))
+	}
+	n := f.qr.r
+	if f.qr.c > n {
+		n = f.qr.c
+	}
+	return float64(n) * eps * max
+}
+
+/*
+Rank returns the numerical rank of the factorized matrix, which is the
+number of diagonal values of R that are larger in magnitude than a tolerance
+based on the largest of them. The rank is only reliable for a factorization
+created with PivotedQR.
+*/
+func (f *QR[T]) Rank() int {
+	tol := f.tol()
+	rank := 0
+	for i := range f.tau {
+		if math.Abs(float64(f.qr.vals[i*f.qr.c+i])) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+/*
+LeastSquares returns the x that minimizes the 2-norm of A * x - b, where A
+is the factorized matrix, which must have at least as many rows as columns,
+and b has as many rows as A. Each column of x is the solution for the
+corresponding column of b.
+
+If the factorization was created with PivotedQR, a rank deficient A is
+handled by returning the basic solution, in which the values of x that
+correspond to the dependent columns of A are zero. Otherwise, a rank
+deficient A results in a *SingularMatrixError.
+*/
+func (f *QR[T]) LeastSquares(b *Mat[T]) *Mat[T] {
+	x, err := f.LeastSquaresE(b)
+	if err != nil {
+		handleErr(err)
+	}
+	return x
+}
+
+/*
+LeastSquaresE is the same as LeastSquares, except that it returns an error
+instead of exiting the program.
+*/
+func (f *QR[T]) LeastSquaresE(b *Mat[T]) (*Mat[T], error) {
+	r, c := f.qr.r, f.qr.c
+	if r < c {
+		return nil, &ArgumentError{
+			Op:  "LeastSquares()",
+			Msg: "the factorized matrix must have at least as many rows as columns",
+		}
+	}
+	if b.r != r {
+		return nil, &ShapeMismatchError{
+			Op:   "LeastSquares()",
+			Want: []int{r, b.c},
+			Got:  []int{b.r, b.c},
+		}
+	}
+	rank := f.Rank()
+	if rank < c && !f.pivoted {
+		return nil, &SingularMatrixError{Op: "LeastSquares()", Cond: math.Inf(1)}
+	}
+	qtb := b.Copy()
+	f.applyQT(qtb)
+	a := f.qr.vals
+	x := New[T](c, b.c)
+	z := make([]T, rank)
+	for jj := 0; jj < b.c; jj++ {
+		for i := rank - 1; i >= 0; i-- {
+			s := qtb.vals[i*b.c+jj]
+			for k := i + 1; k < rank; k++ {
+				s -= a[i*c+k] * z[k]
+			}
+			z[i] = s / a[i*c+i]
+		}
+		for i := 0; i < rank; i++ {
+			x.vals[f.piv[i]*b.c+jj] = z[i]
+		}
+	}
+	return x, nil
+}
@@ -0,0 +1,101 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{12, -51, 4},
+		{6, 167, -68},
+		{-4, 24, -41},
+		{-1, 1, 0},
+	})
+	for _, f := range []*QR[float64]{m.QR(), m.PivotedQR()} {
+		q, r := f.Q(), f.R()
+		rows, cols := q.Shape()
+		assert.Equal(t, 4, rows, "should be equal")
+		assert.Equal(t, 3, cols, "should be equal")
+		qtq := q.T().Dot(q)
+		assert.InDeltaSlice(t, I[float64](3).vals, qtq.vals, 1e-12, "Q should be orthonormal")
+		for i := 1; i < 3; i++ {
+			for j := 0; j < i; j++ {
+				assert.Equal(t, 0.0, r.Get(i, j), "R should be upper triangular")
+			}
+		}
+		ap := New[float64](4, 3)
+		for j, p := range f.Pivot() {
+			ap.SetCol(j, m.Col(p).ToSlice1D())
+		}
+		assert.InDeltaSlice(t, ap.vals, q.Dot(r).vals, 1e-10, "A*P should equal Q*R")
+		assert.Equal(t, 3, f.Rank(), "should be full rank")
+	}
+}
+
+func TestLeastSquaresf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	// Fit y = 1 + 2x to points on the line.
+	a := Matf64FromData([][]float64{
+		{1, 0},
+		{1, 1},
+		{1, 2},
+		{1, 3},
+	})
+	b := Matf64FromData([]float64{1, 3, 5, 7}, 4)
+	x := a.QR().LeastSquares(b)
+	assert.InDeltaSlice(t, []float64{1, 2}, x.vals, 1e-12, "should be equal")
+
+	// The regression line of these noisy points is y = 1.1 + 2.1x.
+	b = Matf64FromData([]float64{1, 4, 4, 8}, 4)
+	x = a.QR().LeastSquares(b)
+	assert.InDeltaSlice(t, []float64{1.1, 2.1}, x.vals, 1e-12, "should be equal")
+
+	// The third column is the sum of the first two.
+	d := Matf64FromData([][]float64{
+		{1, 0, 1},
+		{1, 1, 2},
+		{1, 2, 3},
+		{1, 3, 4},
+	})
+	_, err := d.QR().LeastSquaresE(b)
+	var e *SingularMatrixError
+	assert.True(t, errors.As(err, &e), "should be rank deficient")
+	f := d.PivotedQR()
+	assert.Equal(t, 2, f.Rank(), "should be equal")
+	x = f.LeastSquares(b)
+	assert.Equal(t, 0.0, x.Get(f.Pivot()[2], 0), "should be a basic solution")
+	assert.InDeltaSlice(t, a.Dot(Matf64FromData([]float64{1.1, 2.1}, 2)).vals,
+		d.Dot(x).vals, 1e-12, "should be the least squares fit")
+
+	// Rank deficient, with the columns already in pivot order.
+	g := Matf64FromData([][]float64{
+		{1, 0},
+		{0, 0},
+		{0, 0},
+	}).PivotedQR()
+	assert.Equal(t, []int{0, 1}, g.Pivot(), "should need no swaps")
+	assert.Equal(t, 1, g.Rank(), "should be equal")
+	x, err = g.LeastSquaresE(Matf64FromData([]float64{2, 1, 1}, 3))
+	assert.Nil(t, err, "should be a basic solution")
+	assert.Equal(t, []float64{2, 0}, x.vals, "should be equal")
+
+	assert.Panics(t, func() { a.T().QR().LeastSquares(Newf64(2, 1)) }, "wide system")
+	assert.Panics(t, func() { a.QR().LeastSquares(Newf64(3, 1)) }, "wrong shape")
+}
+
+func TestQRf32(t *testing.T) {
+	t.Helper()
+	a := Matf32FromData([][]float32{
+		{1, 0},
+		{1, 1},
+		{1, 2},
+	})
+	b := Matf32FromData([]float32{1, 3, 5}, 3)
+	x := a.QR().LeastSquares(b)
+	assert.InDeltaSlice(t, []float32{1, 2}, x.vals, 1e-5, "should be equal")
+}
@@ -0,0 +1,266 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+SumAxis returns the sums of each row or each column of a Mat, computed in a
+single pass over its values. The passed axis follows the same convention as
+Sum: 0 sums each row into an r by 1 matrix, while 1 sums each column into a
+1 by c matrix. For example:
+
+	m := matrix.Matf64FromData([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	m.SumAxis(0) // [[6], [15]]
+	m.SumAxis(1) // [[5, 7, 9]]
+*/
+func (m *Mat[T]) SumAxis(axis int) *Mat[T] {
+	o, err := m.SumAxisE(axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+SumAxisE is the same as SumAxis, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) SumAxisE(axis int) (*Mat[T], error) {
+	return m.sumAxis("SumAxis()", axis)
+}
+
+/*
+MeanAxis returns the averages of each row or each column of a Mat, in the
+same way as SumAxis.
+*/
+func (m *Mat[T]) MeanAxis(axis int) *Mat[T] {
+	o, err := m.MeanAxisE(axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+MeanAxisE is the same as MeanAxis, except that it returns an error instead
+of exiting the program.
+*/
+func (m *Mat[T]) MeanAxisE(axis int) (*Mat[T], error) {
+	return m.meanAxis("MeanAxis()", axis)
+}
+
+/*
+StdAxis returns the population standard deviations of each row or each
+column of a Mat, in the same way as SumAxis. Each standard deviation is
+normalized by the number of values in its row or column. To take the sample
+standard deviations instead, see StdAxisDdof.
+*/
+func (m *Mat[T]) StdAxis(axis int) *Mat[T] {
+	o, err := m.StdAxisE(axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+StdAxisE is the same as StdAxis, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) StdAxisE(axis int) (*Mat[T], error) {
+	return m.stdAxis("StdAxis()", 0, axis)
+}
+
+/*
+StdAxisDdof is the same as StdAxis, except that the sum of the squared
+deviations of each row or column is divided by n - ddof, where n is the
+number of values in it, in the same way as StdDdof:
+
+	m.StdAxisDdof(1, 1) // The sample standard deviation of each column.
+*/
+func (m *Mat[T]) StdAxisDdof(ddof, axis int) *Mat[T] {
+	o, err := m.StdAxisDdofE(ddof, axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+StdAxisDdofE is the same as StdAxisDdof, except that it returns an error
+instead of exiting the program.
+*/
+func (m *Mat[T]) StdAxisDdofE(ddof, axis int) (*Mat[T], error) {
+	return m.stdAxis("StdAxisDdof()", ddof, axis)
+}
+
+/*
+ArgMinAxis returns the indices of the smallest value of each row or each
+column of a Mat, in the same way as SumAxis. For each row, the index is that
+of the column holding the smallest value, and for each column it is that of
+the row. As with Min, the index of the first encountered value is returned
+when there is more than one smallest value.
+*/
+func (m *Mat[T]) ArgMinAxis(axis int) *Mati64 {
+	o, err := m.ArgMinAxisE(axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+ArgMinAxisE is the same as ArgMinAxis, except that it returns an error
+instead of exiting the program.
+*/
+func (m *Mat[T]) ArgMinAxisE(axis int) (*Mati64, error) {
+	return m.argAxis("ArgMinAxis()", axis, func(a, b T) bool { return a < b })
+}
+
+/*
+ArgMaxAxis returns the indices of the biggest value of each row or each
+column of a Mat, in the same way as ArgMinAxis.
+*/
+func (m *Mat[T]) ArgMaxAxis(axis int) *Mati64 {
+	o, err := m.ArgMaxAxisE(axis)
+	if err != nil {
+		handleErr(err)
+	}
+	return o
+}
+
+/*
+ArgMaxAxisE is the same as ArgMaxAxis, except that it returns an error
+instead of exiting the program.
+*/
+func (m *Mat[T]) ArgMaxAxisE(axis int) (*Mati64, error) {
+	return m.argAxis("ArgMaxAxis()", axis, func(a, b T) bool { return a > b })
+}
+
+// axisError is returned by the axis reductions when axis is not 0 or 1.
+func axisError(op string, axis int) error {
+	s := "the axis must be 0 or 1, but %d was received"
+	return &ArgumentError{Op: op, Msg: fmt.Sprintf(s, axis)}
+}
+
+// axisLen returns the number of values which are reduced into each result
+// along the passed axis, which must be 0 or 1.
+func (m *Mat[T]) axisLen(axis int) int {
+	if axis == 0 {
+		return m.c
+	}
+	return m.r
+}
+
+// sumAxis sums the rows or columns of m. The columns are summed by adding
+// each row to the result in turn, which keeps the accesses in memory order.
+func (m *Mat[T]) sumAxis(op string, axis int) (*Mat[T], error) {
+	switch axis {
+	case 0:
+		o := New[T](m.r, 1)
+		for i := 0; i < m.r; i++ {
+			var sum T
+			for _, v := range m.row(i) {
+				sum += v
+			}
+			o.vals[i] = sum
+		}
+		return o, nil
+	case 1:
+		o := New[T](1, m.c)
+		for i := 0; i < m.r; i++ {
+			for j, v := range m.row(i) {
+				o.vals[j] += v
+			}
+		}
+		return o, nil
+	}
+	return nil, axisError(op, axis)
+}
+
+// stdAxis computes the standard deviations of the rows or the columns of m
+// with one Welford accumulator for each of them, which are fed one row at a
+// time to keep the accesses in memory order.
+func (m *Mat[T]) stdAxis(op string, ddof, axis int) (*Mat[T], error) {
+	var o *Mat[T]
+	var acc []welford
+	switch axis {
+	case 0:
+		o, acc = New[T](m.r, 1), make([]welford, m.r)
+		for i := 0; i < m.r; i++ {
+			for _, v := range m.row(i) {
+				acc[i].push(float64(v))
+			}
+		}
+	case 1:
+		o, acc = New[T](1, m.c), make([]welford, m.c)
+		for i := 0; i < m.r; i++ {
+			for j, v := range m.row(i) {
+				acc[j].push(float64(v))
+			}
+		}
+	default:
+		return nil, axisError(op, axis)
+	}
+	for i := range acc {
+		v, err := acc[i].variance(op, ddof)
+		if err != nil {
+			return nil, err
+		}
+		o.vals[i] = T(math.Sqrt(v))
+	}
+	return o, nil
+}
+
+func (m *Mat[T]) meanAxis(op string, axis int) (*Mat[T], error) {
+	o, err := m.sumAxis(op, axis)
+	if err != nil {
+		return nil, err
+	}
+	n := T(m.axisLen(axis))
+	for i := range o.vals {
+		o.vals[i] /= n
+	}
+	return o, nil
+}
+
+// argAxis returns the index of the first value of each row or column of m
+// for which no later value v satisfies better(v, current).
+func (m *Mat[T]) argAxis(op string, axis int, better func(a, b T) bool) (*Mati64, error) {
+	if axis != 0 && axis != 1 {
+		return nil, axisError(op, axis)
+	}
+	if m.axisLen(axis) == 0 {
+		return nil, &ArgumentError{Op: op, Msg: "cannot reduce an empty axis"}
+	}
+	if axis == 0 {
+		o := Newi64(m.r, 1)
+		for i := 0; i < m.r; i++ {
+			row := m.row(i)
+			best := 0
+			for j := 1; j < len(row); j++ {
+				if better(row[j], row[best]) {
+					best = j
+				}
+			}
+			o.vals[i] = int64(best)
+		}
+		return o, nil
+	}
+	o := Newi64(1, m.c)
+	best := append([]T(nil), m.row(0)...)
+	for i := 1; i < m.r; i++ {
+		for j, v := range m.row(i) {
+			if better(v, best[j]) {
+				best[j] = v
+				o.vals[j] = int64(i)
+			}
+		}
+	}
+	return o, nil
+}
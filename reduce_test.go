@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	rows := m.SumAxis(0)
+	r, c := rows.Shape()
+	assert.Equal(t, []int{2, 1}, []int{r, c}, "should be r by 1")
+	assert.Equal(t, []float64{6, 15}, rows.vals, "should be equal")
+	cols := m.SumAxis(1)
+	r, c = cols.Shape()
+	assert.Equal(t, []int{1, 3}, []int{r, c}, "should be 1 by c")
+	assert.Equal(t, []float64{5, 7, 9}, cols.vals, "should be equal")
+
+	v := rangeMatf64(4, 4).View(1, 3, 1, 4)
+	assert.Equal(t, []float64{18, 30}, v.SumAxis(0).vals, "view")
+	assert.Equal(t, []float64{14, 16, 18}, v.SumAxis(1).vals, "view")
+
+	_, err := m.SumAxisE(2)
+	assert.NotNil(t, err, "axis must be 0 or 1")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.SumAxis(-1) }, "axis must be 0 or 1")
+}
+
+func TestMeanAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	assert.Equal(t, []float64{2, 5}, m.MeanAxis(0).vals, "should be equal")
+	assert.Equal(t, []float64{2.5, 3.5, 4.5}, m.MeanAxis(1).vals, "should be equal")
+	for j := 0; j < 3; j++ {
+		assert.Equal(t, m.Avg(1, j), m.MeanAxis(1).Get(0, j), "should match Avg")
+	}
+}
+
+func TestStdAxisf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, 4, 4, 4},
+		{5, 5, 7, 9},
+	})
+	assert.InDeltaSlice(t, []float64{math.Sqrt(0.75), math.Sqrt(2.75)}, m.StdAxis(0).vals,
+		1e-12, "should be equal")
+	assert.InDeltaSlice(t, []float64{1.5, 0.5, 1.5, 2.5}, m.StdAxis(1).vals, 1e-12,
+		"should be equal")
+	assert.InDeltaSlice(t, []float64{1, math.Sqrt(11.0 / 3)}, m.StdAxisDdof(1, 0).vals,
+		1e-12, "should be equal")
+	s := m.StdAxisDdof(1, 1)
+	for j := 0; j < 4; j++ {
+		assert.InDelta(t, m.StdDdof(1, 1, j), s.Get(0, j), 1e-12, "should match StdDdof")
+	}
+
+	// A large offset does not change the result of a stable algorithm.
+	n := Matf64FromData([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, 4, 1)
+	assert.InDelta(t, math.Sqrt(22.5), n.StdAxis(1).Get(0, 0), 1e-12, "should be equal")
+
+	_, err := m.StdAxisE(3)
+	assert.NotNil(t, err, "axis must be 0 or 1")
+	_, err = m.StdAxisDdofE(2, 1)
+	assert.NotNil(t, err, "ddof must be less than the column length")
+	_, err = m.StdAxisDdofE(-1, 0)
+	assert.NotNil(t, err, "ddof cannot be negative")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.StdAxisDdof(4, 0) }, "ddof equals the row length")
+}
+
+func TestArgAxisf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{3, 1, 2},
+		{1, 5, 2},
+		{4, 0, 2},
+	})
+	assert.Equal(t, []int64{1, 0, 1}, m.ArgMinAxis(0).vals, "should be equal")
+	assert.Equal(t, []int64{1, 2, 0}, m.ArgMinAxis(1).vals, "first of equal values")
+	assert.Equal(t, []int64{0, 1, 0}, m.ArgMaxAxis(0).vals, "should be equal")
+	assert.Equal(t, []int64{2, 1, 0}, m.ArgMaxAxis(1).vals, "first of equal values")
+	r, c := m.ArgMaxAxis(1).Shape()
+	assert.Equal(t, []int{1, 3}, []int{r, c}, "should be 1 by c")
+
+	_, err := Newf32(0, 3).ArgMinAxisE(1)
+	assert.NotNil(t, err, "empty axis")
+	_, err = m.ArgMaxAxisE(2)
+	assert.NotNil(t, err, "axis must be 0 or 1")
+}
@@ -0,0 +1,326 @@
+package matrix
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+SVD is the singular value decomposition of an r by c matrix A, such that
+A = U * diag(S) * transpose(V), where the columns of U and V are orthonormal,
+and S holds the non-negative singular values in decreasing order. It is
+computed with one-sided Jacobi rotations, and is created with the SVD or the
+FullSVD method of Matf64 or Matf32.
+
+For the thin decomposition returned by SVD, U is r by k, S has k values and
+V is c by k, where k is the smaller of r and c. For the full decomposition
+returned by FullSVD, U is r by r and V is c by c, while S still has k
+values.
+*/
+type SVD[T Float] struct {
+	u, v *Mat[T]
+	s    []T
+}
+
+/*
+SVD computes the thin singular value decomposition of a matrix. The receiver
+is not modified.
+*/
+func (m *Mat[T]) SVD() *SVD[T] {
+	return m.svd(false)
+}
+
+/*
+FullSVD computes the full singular value decomposition of a matrix, in which
+U and V are square. The receiver is not modified.
+*/
+func (m *Mat[T]) FullSVD() *SVD[T] {
+	return m.svd(true)
+}
+
+func (m *Mat[T]) svd(full bool) *SVD[T] {
+	if m.r < m.c {
+		f := m.T().svd(full)
+		f.u, f.v = f.v, f.u
+		return f
+	}
+	r, c := m.r, m.c
+	u := m.Copy()
+	v := I[T](c)
+	eps := epsilon[T]()
+	for sweep := 0; sweep < 75; sweep++ {
+		rotated := false
+		for j := 0; j < c-1; j++ {
+			for k := j + 1; k < c; k++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < r; i++ {
+					x, y := float64(u.vals[i*c+j]), float64(u.vals[i*c+k])
+					alpha += x * x
+					beta += y * y
+					gamma += x * y
+				}
+				if gamma == 0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				cs := 1 / math.Sqrt(1+t*t)
+				sn := cs * t
+				rotateCols(u, j, k, T(cs), T(sn))
+				rotateCols(v, j, k, T(cs), T(sn))
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	s := make([]T, c)
+	for j := 0; j < c; j++ {
+		var norm float64
+		for i := 0; i < r; i++ {
+			norm = math.Hypot(norm, float64(u.vals[i*c+j]))
+		}
+		s[j] = T(norm)
+	}
+	order := make([]int, c)
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return s[order[a]] > s[order[b]] })
+	f := &SVD[T]{u: New[T](r, c), v: New[T](c, c), s: make([]T, c)}
+	var tol float64
+	if c > 0 {
+		tol = float64(r) * eps * float64(s[order[0]])
+	}
+	known := 0
+	for jj, j := range order {
+		f.s[jj] = s[j]
+		for i := 0; i < c; i++ {
+			f.v.vals[i*c+jj] = v.vals[i*c+j]
+		}
+		if float64(s[j]) > tol {
+			for i := 0; i < r; i++ {
+				f.u.vals[i*c+jj] = u.vals[i*c+j] / s[j]
+			}
+			known++
+		}
+	}
+	cols := c
+	if full {
+		cols = r
+	}
+	f.u = completeBasis(f.u, known, cols)
+	return f
+}
+
+// rotateCols applies a plane rotation to the columns j and k of m.
+func rotateCols[T Float](m *Mat[T], j, k int, cs, sn T) {
+	for i := 0; i < m.r; i++ {
+		x, y := m.vals[i*m.c+j], m.vals[i*m.c+k]
+		m.vals[i*m.c+j] = cs*x - sn*y
+		m.vals[i*m.c+k] = sn*x + cs*y
+	}
+}
+
+// completeBasis returns a matrix with cols orthonormal columns, the first
+// known of which are the first known columns of m. The others are found by
+// orthogonalizing the unit vectors against the columns found so far.
+func completeBasis[T Float](m *Mat[T], known, cols int) *Mat[T] {
+	r := m.r
+	q := New[T](r, cols)
+	for j := 0; j < known; j++ {
+		for i := 0; i < r; i++ {
+			q.vals[i*cols+j] = m.vals[i*m.c+j]
+		}
+	}
+	w := make([]float64, r)
+	for j, e := known, 0; j < cols && e < r; e++ {
+		for i := range w {
+			w[i] = 0
+		}
+		w[e] = 1
+		for pass := 0; pass < 2; pass++ {
+			for jj := 0; jj < j; jj++ {
+				var d float64
+				for i := 0; i < r; i++ {
+					d += float64(q.vals[i*cols+jj]) * w[i]
+				}
+				for i := 0; i < r; i++ {
+					w[i] -= d * float64(q.vals[i*cols+jj])
+				}
+			}
+		}
+		var norm float64
+		for i := range w {
+			norm = math.Hypot(norm, w[i])
+		}
+		if norm < 0.5 {
+			continue
+		}
+		for i := 0; i < r; i++ {
+			q.vals[i*cols+j] = T(w[i] / norm)
+		}
+		j++
+	}
+	return q
+}
+
+/*
+U returns a copy of the left singular vectors, stored as columns.
+*/
+func (f *SVD[T]) U() *Mat[T] {
+	return f.u.Copy()
+}
+
+/*
+S returns a copy of the singular values, in decreasing order.
+*/
+func (f *SVD[T]) S() []T {
+	s := make([]T, len(f.s))
+	copy(s, f.s)
+	return s
+}
+
+/*
+V returns a copy of the right singular vectors, stored as columns.
+*/
+func (f *SVD[T]) V() *Mat[T] {
+	return f.v.Copy()
+}
+
+// defaultTol returns the tolerance below which singular values are treated
+// as zero when none is given.
+func (f *SVD[T]) defaultTol() float64 {
+	if len(f.s) == 0 {
+		return 0
+	}
+	n := f.u.r
+	if f.v.r > n {
+		n = f.v.r
+	}
+	return float64(n) * epsilon[T]() * float64(f.s[0])
+}
+
+/*
+Rank returns the number of singular values which are larger than tol. If tol
+is negative, the tolerance max(r, c) * eps * S()[0] is used, where eps is
+the machine epsilon of the element type.
+*/
+func (f *SVD[T]) Rank(tol float64) int {
+	if tol < 0 {
+		tol = f.defaultTol()
+	}
+	rank := 0
+	for _, s := range f.s {
+		if float64(s) > tol {
+			rank++
+		}
+	}
+	return rank
+}
+
+/*
+Cond returns the 2-norm condition number of the decomposed matrix, which is
+the ratio of its largest and smallest singular values. It is +Inf for a
+singular matrix.
+*/
+func (f *SVD[T]) Cond() float64 {
+	if len(f.s) == 0 {
+		return 0
+	}
+	last := float64(f.s[len(f.s)-1])
+	if last == 0 {
+		return math.Inf(1)
+	}
+	return float64(f.s[0]) / last
+}
+
+/*
+PseudoInverse returns the Moore-Penrose pseudo-inverse of the decomposed
+matrix, which is c by r. Singular values which are not larger than the
+default tolerance of Rank are treated as zero, which makes the result
+well behaved for singular and nearly singular matrices.
+*/
+func (f *SVD[T]) PseudoInverse() *Mat[T] {
+	rank := f.Rank(-1)
+	r, c := f.u.r, f.v.r
+	p := New[T](c, r)
+	uc, vc := f.u.c, f.v.c
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			var sum T
+			for k := 0; k < rank; k++ {
+				sum += f.v.vals[i*vc+k] * f.u.vals[j*uc+k] / f.s[k]
+			}
+			p.vals[i*r+j] = sum
+		}
+	}
+	return p
+}
+
+/*
+LowRank returns the best approximation of the decomposed matrix with a rank
+of at most k, in both the 2-norm and the Frobenius norm, which is formed
+from the k largest singular values and their singular vectors.
+*/
+func (f *SVD[T]) LowRank(k int) *Mat[T] {
+	a, err := f.LowRankE(k)
+	if err != nil {
+		handleErr(err)
+	}
+	return a
+}
+
+/*
+LowRankE is the same as LowRank, except that it returns an error instead of
+exiting the program.
+*/
+func (f *SVD[T]) LowRankE(k int) (*Mat[T], error) {
+	if k < 0 || k > len(f.s) {
+		return nil, &IndexOutOfRangeError{
+			Op:    "LowRank()",
+			Axis:  1,
+			Index: k,
+			Bound: len(f.s) + 1,
+		}
+	}
+	r, c := f.u.r, f.v.r
+	a := New[T](r, c)
+	uc, vc := f.u.c, f.v.c
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			var sum T
+			for l := 0; l < k; l++ {
+				sum += f.u.vals[i*uc+l] * f.s[l] * f.v.vals[j*vc+l]
+			}
+			a.vals[i*c+j] = sum
+		}
+	}
+	return a, nil
+}
+
+/*
+PseudoInverse returns the Moore-Penrose pseudo-inverse of a matrix, computed
+through its singular value decomposition. The receiver is not modified.
+*/
+func (m *Mat[T]) PseudoInverse() *Mat[T] {
+	return m.SVD().PseudoInverse()
+}
+
+/*
+Rank returns the numerical rank of a matrix, which is the number of its
+singular values that are larger than tol. If tol is negative, a default
+tolerance is used, as described in (*SVD).Rank.
+*/
+func (m *Mat[T]) Rank(tol float64) int {
+	return m.SVD().Rank(tol)
+}
+
+/*
+Cond returns the 2-norm condition number of a matrix, which is the ratio of
+its largest and smallest singular values.
+*/
+func (m *Mat[T]) Cond() float64 {
+	return m.SVD().Cond()
+}
@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func diagf64(r, c int, s []float64) *Matf64 {
+	d := Newf64(r, c)
+	for i, v := range s {
+		d.Set(i, i, v)
+	}
+	return d
+}
+
+func TestSVDf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{3, 2, 2},
+		{2, 3, -2},
+	})
+	for _, a := range []*Matf64{m, m.T()} {
+		f := a.SVD()
+		assert.InDeltaSlice(t, []float64{5, 3}, f.S(), 1e-12, "should be equal")
+		u, v := f.U(), f.V()
+		k := len(f.S())
+		assert.InDeltaSlice(t, I[float64](k).vals, u.T().Dot(u).vals, 1e-12,
+			"should be orthonormal")
+		assert.InDeltaSlice(t, I[float64](k).vals, v.T().Dot(v).vals, 1e-12,
+			"should be orthonormal")
+		usv := u.Dot(diagf64(k, k, f.S())).Dot(v.T())
+		assert.InDeltaSlice(t, a.vals, usv.vals, 1e-12, "should reconstruct")
+
+		full := a.FullSVD()
+		u, v = full.U(), full.V()
+		r, c := a.Shape()
+		assert.Equal(t, []int{r, r, c, c}, []int{u.r, u.c, v.r, v.c}, "should be square")
+		assert.InDeltaSlice(t, I[float64](r).vals, u.T().Dot(u).vals, 1e-12,
+			"should be orthonormal")
+		assert.InDeltaSlice(t, I[float64](c).vals, v.T().Dot(v).vals, 1e-12,
+			"should be orthonormal")
+		usv = u.Dot(diagf64(r, c, full.S())).Dot(v.T())
+		assert.InDeltaSlice(t, a.vals, usv.vals, 1e-12, "should reconstruct")
+	}
+	assert.InDelta(t, 5.0/3.0, m.Cond(), 1e-12, "should be equal")
+	assert.Equal(t, 2, m.Rank(-1), "should be equal")
+	assert.Equal(t, 1, m.Rank(4), "should be equal")
+}
+
+func TestPseudoInversef64(t *testing.T) {
+	t.Helper()
+	// A rank one matrix, which has no inverse.
+	m := Matf64FromData([][]float64{
+		{1, 2},
+		{2, 4},
+		{3, 6},
+	})
+	f := m.SVD()
+	assert.Equal(t, 1, f.Rank(-1), "should be equal")
+	assert.True(t, math.IsInf(f.Cond(), 1) || f.Cond() > 1e15, "should be singular")
+	p := m.PseudoInverse()
+	assert.Equal(t, []int{2, 3}, []int{p.r, p.c}, "should be transposed")
+	assert.InDeltaSlice(t, m.vals, m.Dot(p).Dot(m).vals, 1e-12, "A*P*A should equal A")
+	assert.InDeltaSlice(t, p.vals, p.Dot(m).Dot(p).vals, 1e-12, "P*A*P should equal P")
+
+	sq := Matf64FromData([][]float64{
+		{4, 7},
+		{2, 6},
+	})
+	assert.InDeltaSlice(t, sq.Inverse().vals, sq.PseudoInverse().vals, 1e-12,
+		"should equal the inverse")
+}
+
+func TestLowRankf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := Matf64FromData([][]float64{
+		{3, 2, 2},
+		{2, 3, -2},
+	})
+	f := m.SVD()
+	assert.InDeltaSlice(t, m.vals, f.LowRank(2).vals, 1e-12, "should be equal")
+	one := f.LowRank(1)
+	assert.Equal(t, 1, one.Rank(-1), "should be equal")
+	assert.InDeltaSlice(t, []float64{2.5, 2.5, 0, 2.5, 2.5, 0}, one.vals, 1e-12,
+		"should be equal")
+	assert.Equal(t, make([]float64, 6), f.LowRank(0).vals, "should be zero")
+	assert.Panics(t, func() { f.LowRank(3) }, "out of range")
+}
+
+func TestSVDf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{2, 0},
+		{0, -3},
+	})
+	f := m.SVD()
+	assert.InDeltaSlice(t, []float32{3, 2}, f.S(), 1e-5, "should be equal")
+	assert.InDelta(t, 1.5, m.Cond(), 1e-5, "should be equal")
+}
@@ -0,0 +1,178 @@
+package matrix
+
+import "reflect"
+
+/*
+TakeRows returns a new Mat made of the rows of the receiver at the passed
+indices, in the order that they are passed. Indices can repeat, and negative
+indices count from the end, as in Row. For example:
+
+	m.TakeRows([]int{2, 0})       // The 3rd and the 1st rows of m.
+	m.TakeRows([]int{-1, -1, -1}) // The last row of m, repeated 3 times.
+
+This can be used to reorder the rows of a dataset, or to sample them.
+*/
+func (m *Mat[T]) TakeRows(rows []int) *Mat[T] {
+	n, err := m.TakeRowsE(rows)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+TakeRowsE is the same as TakeRows, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) TakeRowsE(rows []int) (*Mat[T], error) {
+	idx, err := resolveIndices("TakeRows()", 0, rows, m.r)
+	if err != nil {
+		return nil, err
+	}
+	n := New[T](len(idx), m.c)
+	for i, r := range idx {
+		copy(n.row(i), m.row(r))
+	}
+	return n, nil
+}
+
+/*
+TakeCols returns a new Mat made of the columns of the receiver at the passed
+indices, in the same way as TakeRows.
+*/
+func (m *Mat[T]) TakeCols(cols []int) *Mat[T] {
+	n, err := m.TakeColsE(cols)
+	if err != nil {
+		handleErr(err)
+	}
+	return n
+}
+
+/*
+TakeColsE is the same as TakeCols, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) TakeColsE(cols []int) (*Mat[T], error) {
+	idx, err := resolveIndices("TakeCols()", 1, cols, m.c)
+	if err != nil {
+		return nil, err
+	}
+	n := New[T](m.r, len(idx))
+	for i := 0; i < m.r; i++ {
+		src, dst := m.row(i), n.row(i)
+		for j, c := range idx {
+			dst[j] = src[c]
+		}
+	}
+	return n, nil
+}
+
+/*
+PutRows is the counterpart of TakeRows, and sets the rows of the receiver at
+the passed indices. The passed value can be a float64 or a float32, which
+is set to all the elements of those rows, or a Mat with one row for each
+index and as many columns as the receiver. For example:
+
+	m.PutRows([]int{0, -1}, 0.0)  // Zero the first and the last rows of m.
+	m.PutRows([]int{1, 0}, m.TakeRows([]int{0, 1})) // Swap the first two rows.
+
+When an index repeats, the last of its rows is the one which is kept. It
+returns the receiver.
+*/
+func (m *Mat[T]) PutRows(rows []int, floatOrMat interface{}) *Mat[T] {
+	if err := m.PutRowsE(rows, floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+PutRowsE is the same as PutRows, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) PutRowsE(rows []int, floatOrMat interface{}) error {
+	idx, err := resolveIndices("PutRows()", 0, rows, m.r)
+	if err != nil {
+		return err
+	}
+	at, err := putOperand[T]("PutRows()", floatOrMat, len(idx), m.c)
+	if err != nil {
+		return err
+	}
+	for k, r := range idx {
+		row := m.row(r)
+		for j := range row {
+			row[j] = at(k, j)
+		}
+	}
+	return nil
+}
+
+/*
+PutCols is the counterpart of TakeCols, and sets the columns of the receiver
+at the passed indices, in the same way as PutRows. The passed Mat must have
+as many rows as the receiver, and one column for each index.
+*/
+func (m *Mat[T]) PutCols(cols []int, floatOrMat interface{}) *Mat[T] {
+	if err := m.PutColsE(cols, floatOrMat); err != nil {
+		handleErr(err)
+	}
+	return m
+}
+
+/*
+PutColsE is the same as PutCols, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) PutColsE(cols []int, floatOrMat interface{}) error {
+	idx, err := resolveIndices("PutCols()", 1, cols, m.c)
+	if err != nil {
+		return err
+	}
+	at, err := putOperand[T]("PutCols()", floatOrMat, m.r, len(idx))
+	if err != nil {
+		return err
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.row(i)
+		for k, c := range idx {
+			row[c] = at(i, k)
+		}
+	}
+	return nil
+}
+
+// resolveIndices checks that the passed indices are in range for an axis of
+// length bound, and returns them with the negative indices counted from the
+// end. The passed slice is not modified.
+func resolveIndices(op string, axis int, indices []int, bound int) ([]int, error) {
+	idx := make([]int, len(indices))
+	for i, x := range indices {
+		if x >= bound || x < -bound {
+			return nil, &IndexOutOfRangeError{Op: op, Axis: axis, Index: x, Bound: bound}
+		}
+		if x < 0 {
+			x += bound
+		}
+		idx[i] = x
+	}
+	return idx, nil
+}
+
+// putOperand returns a function giving the value of floatOrMat at row i and
+// column j, where floatOrMat must be a scalar or an r by c Mat. The values of
+// a Mat are copied first, in case it shares its storage with the receiver.
+func putOperand[T Float](op string, floatOrMat interface{}, r, c int) (func(i, j int) T, error) {
+	if x, ok := scalar[T](floatOrMat); ok {
+		return func(int, int) T { return x }, nil
+	}
+	n, ok := floatOrMat.(*Mat[T])
+	if !ok {
+		return nil, &UnsupportedTypeError{Op: op, Type: reflect.TypeOf(floatOrMat)}
+	}
+	if n.r != r || n.c != c {
+		return nil, &ShapeMismatchError{Op: op, Want: []int{r, c}, Got: []int{n.r, n.c}}
+	}
+	vals := n.ToSlice1D()
+	return func(i, j int) T { return vals[i*c+j] }, nil
+}
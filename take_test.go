@@ -0,0 +1,79 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeRowsf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(3, 2)
+	n := m.TakeRows([]int{2, 0, -1})
+	assert.Equal(t, [][]float64{{4, 5}, {0, 1}, {4, 5}}, n.ToSlice2D(), "should be equal")
+	n.Set(0, 0, 10)
+	assert.Equal(t, 4.0, m.Get(2, 0), "the result is a copy")
+	r, c := m.TakeRows([]int{}).Shape()
+	assert.Equal(t, []int{0, 2}, []int{r, c}, "no rows")
+
+	v := rangeMatf64(4, 4).View(1, 3, 1, 3)
+	assert.Equal(t, [][]float64{{9, 10}, {5, 6}}, v.TakeRows([]int{1, 0}).ToSlice2D(), "view")
+
+	_, err := m.TakeRowsE([]int{0, 3})
+	assert.NotNil(t, err, "out of range")
+	_, err = m.TakeRowsE([]int{-4})
+	assert.NotNil(t, err, "out of range")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.TakeRows([]int{5}) }, "out of range")
+}
+
+func TestTakeColsf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	n := m.TakeCols([]int{-1, 0, 0})
+	assert.Equal(t, [][]float32{{3, 1, 1}, {6, 4, 4}}, n.ToSlice2D(), "should be equal")
+	_, err := m.TakeColsE([]int{3})
+	assert.NotNil(t, err, "out of range")
+}
+
+func TestPutRowsf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(3, 2)
+	m.PutRows([]int{1, 0}, m.TakeRows([]int{0, 1}))
+	assert.Equal(t, []float64{2, 3, 0, 1, 4, 5}, m.vals, "swap rows")
+	m.PutRows([]int{-1}, 9.0)
+	assert.Equal(t, []float64{2, 3, 0, 1, 9, 9}, m.vals, "scalar")
+	m.PutRows([]int{0, 0}, Matf64FromData([][]float64{{7, 7}, {8, 8}}))
+	assert.Equal(t, []float64{8, 8, 0, 1, 9, 9}, m.vals, "the last repeat wins")
+
+	// The rows are read before any of them is written.
+	s := rangeMatf64(3, 2)
+	s.PutRows([]int{1, 2}, s.View(0, 2, 0, 2))
+	assert.Equal(t, []float64{0, 1, 0, 1, 2, 3}, s.vals, "overlapping view")
+
+	assert.NotNil(t, m.PutRowsE([]int{3}, 1.0), "out of range")
+	assert.NotNil(t, m.PutRowsE([]int{0}, Newf64(2, 2)), "shapes mismatch")
+	assert.NotNil(t, m.PutRowsE([]int{0}, []float64{1, 2}), "unsupported type")
+	assert.Equal(t, []float64{8, 8, 0, 1, 9, 9}, m.vals, "errors do not modify m")
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	assert.Panics(t, func() { m.PutRows([]int{-4}, 1.0) }, "out of range")
+}
+
+func TestPutColsf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(2, 3)
+	m.PutCols([]int{0, -1}, Matf64FromData([][]float64{{10, 20}, {30, 40}}))
+	assert.Equal(t, []float64{10, 1, 20, 30, 4, 40}, m.vals, "should be equal")
+	m.PutCols([]int{1}, 0.0)
+	assert.Equal(t, []float64{10, 0, 20, 30, 0, 40}, m.vals, "scalar")
+
+	v := rangeMatf64(3, 3).View(0, 2, 1, 3)
+	v.PutCols([]int{0}, -1.0)
+	assert.Equal(t, [][]float64{{-1, 2}, {-1, 5}}, v.ToSlice2D(), "view")
+
+	assert.NotNil(t, m.PutColsE([]int{0}, Newf64(1, 1)), "shapes mismatch")
+	assert.NotNil(t, m.PutColsE([]int{3}, 0.0), "out of range")
+}
@@ -0,0 +1,117 @@
+package matrix
+
+import "unsafe"
+
+/*
+View returns a matrix which is a view into the block of m made of the rows
+r0 up to, but not including, r1, and the columns c0 up to, but not
+including, c1. The view shares its values with m, so that setting a value
+of the view sets the corresponding value of m, and the other way around. For
+example, to add 1 to the top left 2 by 2 block of m:
+
+	m.View(0, 2, 0, 2).Add(1.0)
+
+All of the methods of Mat work on views, and views of views are allowed.
+Methods which change the shape of a view, such as AppendRow, first give it
+its own copy of the values, after which it no longer shares them with m. To
+get such a copy explicitly, use Copy or Materialize.
+*/
+func (m *Mat[T]) View(r0, r1, c0, c1 int) *Mat[T] {
+	v, err := m.ViewE(r0, r1, c0, c1)
+	if err != nil {
+		handleErr(err)
+	}
+	return v
+}
+
+/*
+ViewE is the same as View, except that it returns an error instead of
+exiting the program.
+*/
+func (m *Mat[T]) ViewE(r0, r1, c0, c1 int) (*Mat[T], error) {
+	for _, x := range []int{r0, r1} {
+		if x < 0 || x > m.r {
+			return nil, &IndexOutOfRangeError{Op: "View()", Axis: 0, Index: x, Bound: m.r}
+		}
+	}
+	for _, x := range []int{c0, c1} {
+		if x < 0 || x > m.c {
+			return nil, &IndexOutOfRangeError{Op: "View()", Axis: 1, Index: x, Bound: m.c}
+		}
+	}
+	if r0 > r1 || c0 > c1 {
+		return nil, &ArgumentError{Op: "View()", Msg: "the start of a range is after its end"}
+	}
+	if r0 == r1 || c0 == c1 {
+		// An empty view has no values to share, and is given its own empty
+		// storage, with a stride that is valid for its shape.
+		return &Mat[T]{r: r1 - r0, c: c1 - c0, stride: c1 - c0, vals: make([]T, 0)}, nil
+	}
+	start, end := r0*m.stride+c0, (r1-1)*m.stride+c1
+	return &Mat[T]{
+		r:      r1 - r0,
+		c:      c1 - c0,
+		stride: m.stride,
+		vals:   m.vals[start:end:end],
+	}, nil
+}
+
+/*
+Materialize gives the receiver its own copy of its values, stored
+contiguously, so that it no longer shares them with the matrix it is a view
+of. It does nothing visible for a matrix which is not a view.
+*/
+func (m *Mat[T]) Materialize() *Mat[T] {
+	n := m.Copy()
+	m.vals, m.stride = n.vals, n.stride
+	return m
+}
+
+// contiguous reports whether the values of m are adjacent in m.vals, which
+// is the case for any matrix that is not a view, and for views which span
+// whole rows of their parent or have a single row.
+func (m *Mat[T]) contiguous() bool {
+	return m.stride == m.c || m.r <= 1
+}
+
+// overlaps reports whether the values of m and n lie in the same part of a
+// backing array, as they may for a Mat and the views into it.
+func overlaps[T Float](m, n *Mat[T]) bool {
+	if len(m.vals) == 0 || len(n.vals) == 0 {
+		return false
+	}
+	size := unsafe.Sizeof(m.vals[0])
+	m0, n0 := uintptr(unsafe.Pointer(&m.vals[0])), uintptr(unsafe.Pointer(&n.vals[0]))
+	m1, n1 := m0+uintptr(len(m.vals))*size, n0+uintptr(len(n.vals))*size
+	return m0 < n1 && n0 < m1
+}
+
+// row returns the values of the i-th row of m.
+func (m *Mat[T]) row(i int) []T {
+	return m.vals[i*m.stride : i*m.stride+m.c]
+}
+
+// each calls f with the values of m in row major order, split into runs
+// which are contiguous in memory. off is the position of the first value of
+// run within m. A matrix which is not a view is a single run.
+func (m *Mat[T]) each(f func(off int, run []T)) {
+	if m.contiguous() {
+		f(0, m.vals[:m.r*m.c])
+		return
+	}
+	for i := 0; i < m.r; i++ {
+		f(i*m.c, m.row(i))
+	}
+}
+
+// eachPair calls f with matching runs of the values of m and n, which must
+// have the same shape, in the same way as each.
+func eachPair[T Float](m, n *Mat[T], f func(a, b []T)) {
+	if m.contiguous() && n.contiguous() {
+		f(m.vals[:m.r*m.c], n.vals[:n.r*n.c])
+		return
+	}
+	for i := 0; i < m.r; i++ {
+		f(m.row(i), n.row(i))
+	}
+}
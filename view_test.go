@@ -0,0 +1,117 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rangeMatf64(r, c int) *Matf64 {
+	m := Newf64(r, c)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	return m
+}
+
+func TestViewf64(t *testing.T) {
+	t.Helper()
+	defer SetErrorHandler(SetErrorHandler(PanicOnError))
+	m := rangeMatf64(4, 5)
+	v := m.View(1, 3, 1, 4)
+	r, c := v.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, [][]float64{{6, 7, 8}, {11, 12, 13}}, v.ToSlice2D(), "should be equal")
+	assert.Equal(t, []float64{6, 7, 8, 11, 12, 13}, v.ToSlice1D(), "should be equal")
+	assert.Equal(t, 57.0, v.Sum(), "should be equal")
+	assert.Equal(t, 19.0, v.Sum(1, 1), "should be equal")
+	idx, val := v.Max()
+	assert.Equal(t, 5, idx, "should be the index within the view")
+	assert.Equal(t, 13.0, val, "should be equal")
+
+	v.Set(0, 0, -1)
+	assert.Equal(t, -1.0, m.Get(1, 1), "should write the parent")
+	v.Add(100.0)
+	assert.Equal(t, 107.0, m.Get(1, 2), "should write the parent")
+	assert.Equal(t, 10.0, m.Get(2, 0), "should not touch the rest of the parent")
+	assert.Equal(t, 14.0, m.Get(2, 4), "should not touch the rest of the parent")
+	v.Map(func(x *float64) { *x = 0 })
+	v.Sub(rangeMatf64(2, 3))
+	assert.Equal(t, []float64{0, -1, -2, -3, -4, -5}, v.ToSlice1D(), "should be equal")
+	assert.Equal(t, -5.0, m.Get(2, 3), "should write the parent")
+
+	w := v.View(1, 2, 0, 3)
+	w.SetAll(9)
+	assert.Equal(t, []float64{10, 9, 9, 9, 14}, m.Row(2).vals, "view of a view")
+	assert.Panics(t, func() { m.View(0, 5, 0, 1) }, "out of range")
+	assert.Panics(t, func() { m.View(2, 1, 0, 1) }, "reversed range")
+	e := m.View(2, 2, 0, 5)
+	assert.Equal(t, 0.0, e.Sum(), "should be empty")
+}
+
+func TestViewCopyf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(4, 5)
+	v := m.View(1, 3, 1, 4)
+	c := v.Copy()
+	assert.Equal(t, 3, c.stride, "should be contiguous")
+	c.Set(0, 0, -1)
+	assert.Equal(t, 6.0, m.Get(1, 1), "copy should not share values")
+	assert.True(t, c.Equals(Matf64FromData([]float64{-1, 7, 8, 11, 12, 13}, 2, 3)),
+		"should be equal")
+	assert.True(t, v.T().Equals(Matf64FromData([]float64{6, 11, 7, 12, 8, 13}, 3, 2)),
+		"should be equal")
+
+	v.Materialize()
+	v.Set(0, 0, -1)
+	assert.Equal(t, 6.0, m.Get(1, 1), "should no longer share values")
+
+	v = m.View(0, 2, 0, 2)
+	v.AppendRow([]float64{1, 1})
+	v.Set(0, 0, -1)
+	assert.Equal(t, 0.0, m.Get(0, 0), "should no longer share values")
+	assert.Equal(t, []float64{-1, 1, 5, 6, 1, 1}, v.vals, "should be equal")
+
+	// A view of whole rows is contiguous, and appending must not clobber the
+	// parent.
+	v = m.View(0, 1, 0, 5)
+	v.AppendRow([]float64{-1, -1, -1, -1, -1})
+	assert.Equal(t, 5.0, m.Get(1, 0), "should not clobber the parent")
+}
+
+func TestViewDotf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(4, 5)
+	a := m.View(0, 2, 0, 3)
+	b := m.View(1, 4, 2, 4)
+	want := a.Copy().Dot(b.Copy())
+	assert.Equal(t, want.vals, a.Dot(b).vals, "should be equal")
+
+	dst := Newf64(4, 4)
+	dst.View(1, 3, 2, 4).Gemm(NoTrans, NoTrans, 1, a, b, 0)
+	assert.Equal(t, want.vals, dst.View(1, 3, 2, 4).ToSlice1D(), "should be equal")
+	assert.Equal(t, 0.0, dst.Sum()-want.Sum(), "should not write outside the view")
+}
+
+func TestEmptyViewf64(t *testing.T) {
+	t.Helper()
+	m := rangeMatf64(3, 3)
+	for _, v := range []*Matf64{m.View(0, 3, 1, 1), m.View(2, 2, 0, 3), m.View(1, 1, 2, 2)} {
+		r, c := v.Shape()
+		assert.Equal(t, 0, r*c, "should be empty")
+		assert.Equal(t, 0.0, v.Sum(), "should be zero")
+		assert.Equal(t, 0, len(v.ToSlice1D()), "should be empty")
+		v.Add(1.0).Mul(v.Copy())
+		v.Map(func(x *float64) { *x = 1 })
+		assert.True(t, v.All(Positive[float64]), "vacuously true")
+		assert.True(t, v.Equals(v.Copy()), "should be equal")
+		n := v.T()
+		assert.Equal(t, []int{c, r}, []int{n.r, n.c}, "should be transposed")
+		assert.Equal(t, []float64{}, Add(v, v).vals, "should be empty")
+	}
+	v := m.View(0, 3, 1, 1)
+	assert.Equal(t, []float64{0, 0, 0}, v.SumAxis(0).vals, "rows of no values")
+	assert.Equal(t, 3, len(v.AppendCol([]float64{1, 2, 3}).ToSlice1D()), "should grow")
+	assert.Equal(t, rangeMatf64(3, 3).vals, m.vals, "m should not change")
+}